@@ -0,0 +1,54 @@
+package csv
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// annotationColumn is the header name of the column appended by AnnotateTo.
+const annotationColumn = "errors"
+
+// AnnotateTo reads the CSV in the same way as Decode, and writes the input CSV with an appended
+// "errors" column to w. The "errors" column contains the validation errors of each line
+// (e.g. "id: target is not a numeric character: value=a"), so business users can open
+// the output in a spreadsheet and fix their data.
+// The output has the same delimiter as the input unless WithOutputDelimiter is set.
+// It returns the same errors as Decode, and an error if it fails to write to w.
+func (c *CSV) AnnotateTo(w io.Writer, structSlicePointer any) []error {
+	writer := csv.NewWriter(w)
+	writer.Comma = c.reader.Comma
+	if c.outputDelimiter != 0 {
+		writer.Comma = c.outputDelimiter
+	}
+
+	_, errs := c.decode(context.Background(), structSlicePointer, 0, nil, func(line int, record []string, rowErrs []error) error {
+		if line == c.skipRows+1 && !c.headerless {
+			return writer.Write(append(record, annotationColumn))
+		}
+		return writer.Write(append(record, annotation(rowErrs)))
+	})
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// annotation returns the summary of the validation errors of a line.
+func annotation(errs []error) string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			messages = append(messages, fmt.Sprintf("%s: %v", rowErr.Column, rowErr.Err))
+			continue
+		}
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
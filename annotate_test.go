@@ -0,0 +1,106 @@
+package csv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCSV_AnnotateTo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("write input CSV with errors column", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name,age
+1,Gina,23
+a,Yulia,25
+3,Den1s,-1
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"alpha"`
+			Age  int    `validate:"gte=0"`
+		}
+		people := make([]person, 0)
+
+		var buf bytes.Buffer
+		errs := c.AnnotateTo(&buf, &people)
+		if len(errs) != 3 {
+			t.Errorf("CSV.AnnotateTo() got errors: %v", errs)
+		}
+
+		want := `id,name,age,errors
+1,Gina,23,
+a,Yulia,25,id: target is not a numeric character: value=a
+3,Den1s,-1,"name: target is not an alphabetic character: value=Den1s; age: target is not greater than or equal to the threshold value: threshold=0, value=-1"
+`
+		if diff := cmp.Diff(buf.String(), want); diff != "" {
+			t.Errorf("CSV.AnnotateTo() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("write TSV from CSV with WithOutputDelimiter", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id,name\n1,Gina\nb,Yulia\n"), WithOutputDelimiter('\t'))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"alpha"`
+		}
+		people := make([]person, 0)
+
+		var buf bytes.Buffer
+		if errs := c.AnnotateTo(&buf, &people); len(errs) != 1 {
+			t.Errorf("CSV.AnnotateTo() got errors: %v", errs)
+		}
+
+		want := "id\tname\terrors\n1\tGina\t\nb\tYulia\tid: target is not a numeric character: value=b\n"
+		if diff := cmp.Diff(buf.String(), want); diff != "" {
+			t.Errorf("CSV.AnnotateTo() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("should return an error if the output delimiter is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, r := range []rune{0, '"', '\n'} {
+			if _, err := NewCSV(bytes.NewBufferString(""), WithOutputDelimiter(r)); err == nil {
+				t.Errorf("NewCSV() with delimiter %q got nil error", r)
+			}
+		}
+	})
+
+	t.Run("write headerless TSV", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("1\tGina\nb\tYulia\n"), WithTabDelimiter(), WithHeaderless())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"alpha"`
+		}
+		people := make([]person, 0)
+
+		var buf bytes.Buffer
+		c.AnnotateTo(&buf, &people)
+
+		want := "1\tGina\t\nb\tYulia\t1: target is not a numeric character: value=b\n"
+		if diff := cmp.Diff(buf.String(), want); diff != "" {
+			t.Errorf("CSV.AnnotateTo() mismatch (-got +want):\n%s", diff)
+		}
+	})
+}
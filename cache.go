@@ -0,0 +1,71 @@
+package csv
+
+import "container/list"
+
+// validationCacheKey is the key of validationCache.
+type validationCacheKey struct {
+	rule  string
+	value string
+}
+
+// validationCacheEntry is the entry of validationCache.
+type validationCacheEntry struct {
+	key validationCacheKey
+	err error
+}
+
+// validationCache is an LRU cache of the validation results keyed by the rule and the value.
+// It is used to skip the expensive validators (e.g. regexp, url.Parse) for repeated values
+// such as country or status.
+type validationCache struct {
+	size    int
+	order   *list.List
+	entries map[validationCacheKey]*list.Element
+}
+
+// newValidationCache returns a new validationCache that holds at most size results.
+func newValidationCache(size int) *validationCache {
+	return &validationCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[validationCacheKey]*list.Element, size),
+	}
+}
+
+// get returns the cached result of the rule for the value.
+func (vc *validationCache) get(rule, value string) (error, bool) { //nolint:revive // the result is an error value, not a failure.
+	e, ok := vc.entries[validationCacheKey{rule: rule, value: value}]
+	if !ok {
+		return nil, false
+	}
+	vc.order.MoveToFront(e)
+	return e.Value.(*validationCacheEntry).err, true //nolint:forcetypeassert // only entries are stored.
+}
+
+// add stores the result of the rule for the value. The least recently used result
+// is evicted if the cache is full.
+func (vc *validationCache) add(rule, value string, err error) {
+	key := validationCacheKey{rule: rule, value: value}
+	if e, ok := vc.entries[key]; ok {
+		vc.order.MoveToFront(e)
+		return
+	}
+
+	vc.entries[key] = vc.order.PushFront(&validationCacheEntry{key: key, err: err})
+	if vc.order.Len() > vc.size {
+		oldest := vc.order.Back()
+		vc.order.Remove(oldest)
+		delete(vc.entries, oldest.Value.(*validationCacheEntry).key) //nolint:forcetypeassert // only entries are stored.
+	}
+}
+
+// isCacheable returns true if the result of the validator depends only on the value.
+// The validators that refer to the other fields, call user functions, accumulate
+// the values or depend on the current time are not cached.
+func isCacheable(v validator) bool {
+	switch v.(type) {
+	case crossFieldValidator, *customValidator, *columnStatValidator, *withinDaysValidator:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("evict the least recently used result", func(t *testing.T) {
+		t.Parallel()
+
+		rule := "email\x000"
+		errInvalid := errors.New("invalid")
+		vc := newValidationCache(2)
+		vc.add(rule, "a", nil)
+		vc.add(rule, "b", errInvalid)
+		if _, ok := vc.get(rule, "a"); !ok {
+			t.Fatal("validationCache.get() got no result for a")
+		}
+		vc.add(rule, "c", nil)
+
+		if _, ok := vc.get(rule, "b"); ok {
+			t.Error("validationCache.get() got a result for the evicted value b")
+		}
+		if err, ok := vc.get(rule, "a"); !ok || err != nil {
+			t.Errorf("validationCache.get() got (%v, %v), want (nil, true)", err, ok)
+		}
+	})
+
+	t.Run("distinguish the rules", func(t *testing.T) {
+		t.Parallel()
+
+		vc := newValidationCache(2)
+		vc.add("lte=5\x000", "7", errors.New("invalid"))
+		if _, ok := vc.get("lte=10\x000", "7"); ok {
+			t.Error("validationCache.get() got a result for the other rule")
+		}
+	})
+
+	t.Run("do not cache the rules that depend on the current time", func(t *testing.T) {
+		t.Parallel()
+
+		if isCacheable(newWithinDaysValidator("2006-01-02", 30)) {
+			t.Error("isCacheable() got true for within_days")
+		}
+		if !isCacheable(newEmailValidator()) {
+			t.Error("isCacheable() got false for email")
+		}
+	})
+}
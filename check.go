@@ -0,0 +1,140 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CheckStruct parses the struct tags of T and reports the misconfigured rules: unknown rule names,
+// malformed rules (e.g. gt=abc) and contradictory rules (e.g. gt=10 with lt=5). The unknown rules
+// are ignored by Decode, so CheckStruct is useful in unit tests to catch a typo in the schema.
+// The opts are the same as NewCSV, e.g. WithValidator to register the custom rule names.
+// The error message of a field is prefixed with "field <name>:".
+func CheckStruct[T any](opts ...Option) []error {
+	c, err := NewCSV(nil, opts...)
+	if err != nil {
+		return []error{err}
+	}
+
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		return []error{NewError(c.i18nLocalizer, ErrInvalidStructID, fmt.Sprintf("type=%v", structType))}
+	}
+
+	errs := make([]error, 0)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		for _, err := range c.checkField(structType, field) {
+			errs = append(errs, fmt.Errorf("field %s: %w", field.Name, err))
+		}
+	}
+	return errs
+}
+
+// checkField returns the misconfigured rules of the field.
+func (c *CSV) checkField(structType reflect.Type, field reflect.StructField) []error {
+	errs := make([]error, 0)
+	if _, err := c.parseNormalizeTag(field.Tag.Get(normalizeTag.String())); err != nil {
+		errs = append(errs, err)
+	}
+
+	tags := field.Tag.Get(validateTag.String())
+	if tags == "" {
+		return errs
+	}
+	for _, t := range strings.Split(tags, ",") {
+		if !c.isKnownRule(t) {
+			name := strings.SplitN(t, "=", 2)[0]
+			errs = append(errs, NewError(c.i18nLocalizer, ErrUnknownRuleID,
+				withSuggestion(c.i18nLocalizer, fmt.Sprintf("rule=%s", name), name, c.ruleNames())))
+		}
+	}
+
+	validators, err := c.parseValidateTag(tags)
+	if err != nil {
+		return append(errs, err)
+	}
+	if err := c.bindFields(structType, validators); err != nil {
+		errs = append(errs, err)
+	}
+	return append(errs, c.checkContradictions(validators)...)
+}
+
+// isKnownRule returns true if the rule is a built-in rule or a custom rule registered by WithValidator.
+// The value of the rule (e.g. "10" of "gt=10") is ignored.
+func (c *CSV) isKnownRule(rule string) bool {
+	if _, ok := c.customValidators[rule]; ok {
+		return true
+	}
+	name := strings.SplitN(rule, "=", 2)[0]
+	for _, v := range validateTagValues {
+		if name == v.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleNames returns the names of the built-in rules and the custom rules.
+func (c *CSV) ruleNames() []string {
+	names := make([]string, 0, len(validateTagValues)+len(c.customValidators))
+	for _, v := range validateTagValues {
+		names = append(names, v.String())
+	}
+	for name := range c.customValidators {
+		names = append(names, name)
+	}
+	return names
+}
+
+// checkContradictions returns an error for each pair of the lower and upper bounds that no value satisfies,
+// e.g. gt=10 with lt=5, gte=5 with lt=5, minlen=10 with maxlen=5.
+func (c *CSV) checkContradictions(validators validators) []error {
+	type bound struct {
+		rule      string
+		threshold float64
+		strict    bool
+	}
+	var lowers, uppers, minLens, maxLens, minItems, maxItems []bound
+	for _, v := range validators {
+		switch v := v.(type) {
+		case *greaterThanValidator:
+			lowers = append(lowers, bound{greaterThanTagValue.String(), v.threshold, true})
+		case *greaterThanEqualValidator:
+			lowers = append(lowers, bound{greaterThanEqualTagValue.String(), v.threshold, false})
+		case *minValidator:
+			lowers = append(lowers, bound{minTagValue.String(), v.threshold, false})
+		case *lessThanValidator:
+			uppers = append(uppers, bound{lessThanTagValue.String(), v.threshold, true})
+		case *lessThanEqualValidator:
+			uppers = append(uppers, bound{lessThanEqualTagValue.String(), v.threshold, false})
+		case *maxValidator:
+			uppers = append(uppers, bound{maxTagValue.String(), v.threshold, false})
+		case *minLengthValidator:
+			minLens = append(minLens, bound{minLengthTagValue.String(), v.threshold, false})
+		case *maxLengthValidator:
+			maxLens = append(maxLens, bound{maxLengthTagValue.String(), v.threshold, false})
+		case *minItemsValidator:
+			minItems = append(minItems, bound{minItemsTagValue.String(), v.threshold, false})
+		case *maxItemsValidator:
+			maxItems = append(maxItems, bound{maxItemsTagValue.String(), v.threshold, false})
+		}
+	}
+
+	errs := make([]error, 0)
+	check := func(lowers, uppers []bound) {
+		for _, l := range lowers {
+			for _, u := range uppers {
+				if l.threshold > u.threshold || (l.threshold == u.threshold && (l.strict || u.strict)) {
+					errs = append(errs, NewError(c.i18nLocalizer, ErrContradictoryRulesID,
+						fmt.Sprintf("%s=%v, %s=%v", l.rule, l.threshold, u.rule, u.threshold)))
+				}
+			}
+		}
+	}
+	check(lowers, uppers)
+	check(minLens, maxLens)
+	check(minItems, maxItems)
+	return errs
+}
@@ -0,0 +1,68 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckStruct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return no error if the rules are valid", func(t *testing.T) {
+		t.Parallel()
+
+		type person struct {
+			ID      int    `validate:"numeric,gte=1,lte=100"`
+			Name    string `normalize:"trim" validate:"alpha,minlen=1,maxlen=10"`
+			Age     int    `validate:"min=0,max=0"`
+			Country string `validate:"oneof=JP US,after_start"`
+			Note    string
+		}
+
+		fn := func(_ RowContext, _ string) error { return nil }
+		if errs := CheckStruct[person](WithValidator("after_start", fn)); len(errs) != 0 {
+			t.Errorf("CheckStruct() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return the misconfigured rules", func(t *testing.T) {
+		t.Parallel()
+
+		type person struct {
+			ID    int    `validate:"requried,numeric"`
+			Age   int    `validate:"gt=10,lt=5"`
+			Score int    `validate:"gte=5,lt=5"`
+			Name  string `validate:"minlen=10,maxlen=5"`
+			Code  string `validate:"eq=abc"`
+		}
+
+		errs := CheckStruct[person]()
+		want := []string{
+			"field ID: unknown validation rule: rule=requried (did you mean 'required'?)",
+			"field Age: validation rules contradict each other: gt=10, lt=5",
+			"field Score: validation rules contradict each other: gte=5, lt=5",
+			"field Name: validation rules contradict each other: minlen=10, maxlen=5",
+			"field Code: threshold format is invalid: eq=abc",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CheckStruct() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CheckStruct() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+		if !errors.Is(errs[0], NewError(nil, ErrUnknownRuleID, "")) {
+			t.Errorf("CheckStruct() got error %v, want ErrUnknownRule", errs[0])
+		}
+	})
+
+	t.Run("should return an error if T is not a struct", func(t *testing.T) {
+		t.Parallel()
+
+		errs := CheckStruct[int]()
+		if len(errs) != 1 || errs[0].Error() != "target is not a struct: type=int" {
+			t.Errorf("CheckStruct() got errors: %v", errs)
+		}
+	})
+}
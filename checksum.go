@@ -0,0 +1,132 @@
+package csv
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// ChecksumFunc is a checksum algorithm. It returns true if the check digits of the value are valid.
+type ChecksumFunc func(value string) bool
+
+// builtinChecksums is the checksum algorithms that can be used without WithChecksum.
+var builtinChecksums = map[string]ChecksumFunc{
+	luhnTagValue.String():  luhn,
+	mod97TagValue.String(): mod97,
+	dammTagValue.String():  damm,
+}
+
+// checksum returns the checksum algorithm registered with the name.
+// The algorithms registered by WithChecksum take precedence over the built-in algorithms.
+func (c *CSV) checksum(name string) (ChecksumFunc, bool) {
+	if fn, ok := c.checksums[name]; ok {
+		return fn, true
+	}
+	fn, ok := builtinChecksums[name]
+	return fn, ok
+}
+
+// checksumValidator is a struct that contains the validation rules for a checksum column.
+type checksumValidator struct {
+	name string
+	fn   ChecksumFunc
+}
+
+// newChecksumValidator returns a new checksumValidator.
+func newChecksumValidator(name string, fn ChecksumFunc) *checksumValidator {
+	return &checksumValidator{name: name, fn: fn}
+}
+
+// Do validates the check digits of the target.
+func (c *checksumValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrChecksumID, fmt.Sprintf("checksum=%s, value=%v", c.name, target))
+	}
+
+	if !c.fn(v) {
+		return NewError(localizer, ErrChecksumID, fmt.Sprintf("checksum=%s, value=%v", c.name, target))
+	}
+	return nil
+}
+
+// luhn validates the value with the Luhn algorithm (e.g. credit card numbers).
+func luhn(value string) bool {
+	if len(value) < 2 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(value) - 1; i >= 0; i-- {
+		r := rune(value[i])
+		if !isNumeric(r) {
+			return false
+		}
+		d := int(r - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// mod97 validates the value with ISO 7064 MOD 97-10.
+// Letters are converted to numbers (A=10, B=11, ..., Z=35) and the remainder of
+// the number divided by 97 must be 1. IBAN must be rearranged (the first four
+// characters are moved to the end) before validation.
+func mod97(value string) bool {
+	if len(value) < 2 {
+		return false
+	}
+
+	remainder := 0
+	for _, r := range value {
+		switch {
+		case isNumeric(r):
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		case r >= 'a' && r <= 'z':
+			remainder = (remainder*100 + int(r-'a') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+// dammTable is the quasigroup table of the Damm algorithm.
+var dammTable = [10][10]int{
+	{0, 3, 1, 7, 5, 9, 8, 6, 4, 2},
+	{7, 0, 9, 2, 1, 5, 4, 8, 6, 3},
+	{4, 2, 0, 6, 8, 7, 1, 3, 5, 9},
+	{1, 7, 5, 0, 9, 8, 3, 4, 2, 6},
+	{6, 1, 2, 3, 0, 4, 5, 9, 7, 8},
+	{3, 6, 7, 4, 2, 0, 9, 5, 8, 1},
+	{5, 8, 6, 9, 7, 2, 0, 1, 3, 4},
+	{8, 9, 4, 5, 3, 6, 2, 0, 1, 7},
+	{9, 4, 3, 8, 6, 1, 7, 2, 0, 5},
+	{2, 5, 8, 1, 4, 3, 6, 7, 9, 0},
+}
+
+// damm validates the value with the Damm algorithm.
+func damm(value string) bool {
+	if len(value) < 2 {
+		return false
+	}
+
+	interim := 0
+	for _, r := range value {
+		if !isNumeric(r) {
+			return false
+		}
+		interim = dammTable[interim][r-'0']
+	}
+	return interim == 0
+}
@@ -0,0 +1,105 @@
+package csv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/motemen/go-testutil/dataloc"
+)
+
+func Test_checksum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		fn    ChecksumFunc
+		value string
+		want  bool
+	}{
+		{name: "luhn: valid credit card number", fn: luhn, value: "4111111111111111", want: true},
+		{name: "luhn: valid number with odd length", fn: luhn, value: "79927398713", want: true},
+		{name: "luhn: invalid check digit", fn: luhn, value: "79927398710", want: false},
+		{name: "luhn: not a number", fn: luhn, value: "7992739871a", want: false},
+		{name: "luhn: too short", fn: luhn, value: "0", want: false},
+		{name: "mod97: valid rearranged IBAN", fn: mod97, value: "3214282912345698765432161182", want: true},
+		{name: "mod97: valid rearranged IBAN with letters", fn: mod97, value: "WEST12345698765432GB82", want: true},
+		{name: "mod97: invalid check digits", fn: mod97, value: "WEST12345698765432GB83", want: false},
+		{name: "mod97: invalid character", fn: mod97, value: "WEST-12345698765432GB82", want: false},
+		{name: "damm: valid number", fn: damm, value: "5724", want: true},
+		{name: "damm: invalid check digit", fn: damm, value: "5727", want: false},
+		{name: "damm: not a number", fn: damm, value: "57a4", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.fn(tt.value); got != tt.want {
+				t.Errorf("checksum(%q) = %v, want %v, test case at %s", tt.value, got, tt.want, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func TestWithChecksum(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validate built-in and custom checksum", func(t *testing.T) {
+		t.Parallel()
+
+		input := `card,code,ref
+4111111111111111,AB-00,5724
+4111111111111112,AB-01,5727
+`
+		// zeroSuffix is a custom checksum that the last two characters must be "00".
+		zeroSuffix := func(value string) bool {
+			return strings.HasSuffix(value, "00")
+		}
+
+		c, err := NewCSV(bytes.NewBufferString(input), WithChecksum("zero_suffix", zeroSuffix))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type payment struct {
+			Card string `validate:"luhn"`
+			Code string `validate:"checksum=zero_suffix"`
+			Ref  string `validate:"checksum=damm"`
+		}
+		payments := make([]payment, 0)
+
+		errs := c.Decode(&payments)
+		want := []string{
+			"line:3 column card: target has invalid check digits: checksum=luhn, value=4111111111111112",
+			"line:3 column code: target has invalid check digits: checksum=zero_suffix, value=AB-01",
+			"line:3 column ref: target has invalid check digits: checksum=damm, value=5727",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("should return an error if the checksum algorithm is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("code\n1\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type payment struct {
+			Code string `validate:"checksum=verhoeff"`
+		}
+		payments := make([]payment, 0)
+
+		errs := c.Decode(&payments)
+		if len(errs) != 1 || errs[0].Error() != "'checksum' tag format is invalid or the algorithm is unknown: checksum=verhoeff" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+}
@@ -4,11 +4,14 @@
 package csv
 
 import (
+	"context"
 	"embed"
 	"encoding/csv"
 	"fmt"
 	"io"
+	"io/fs"
 	"reflect"
+	"sort"
 	"strconv"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
@@ -27,15 +30,91 @@ type CSV struct {
 	reader *csv.Reader
 	// header is a type that represents the header of a csv.
 	header header
+	// line is the line number of the next record to be read.
+	// It is 0 until the first record (or the header) is read.
+	line int
 	// ruleSets is slice of ruleSet.
 	// The order of the ruleSet is the same as the order of the columns in the csv.
 	ruleSet ruleSet
+	// ruleKeys is the keys of the validation cache for each validator of the ruleSet.
+	ruleKeys [][]string
+	// structType is the struct type that the ruleSet is parsed from. The ruleSet is parsed once per struct type.
+	structType reflect.Type
+	// lookupsApplied is true if the lookup validators of WithLookup are added to the ruleSet.
+	lookupsApplied bool
+	// normalizerSet is slice of normalizers.
+	// The order of the normalizerSet is the same as the order of the columns in the csv.
+	normalizerSet []normalizers
+	// defaults is the values set by the default tags. The key is the column index.
+	defaults map[int]string
+	// headerAliases is the map of header names set by WithHeaderAliases.
+	// The key is the header name in the CSV and the value is the name used instead.
+	headerAliases map[string]string
+	// lookups is the map of lookup validators specified by WithLookup.
+	// The key is the header name of the column.
+	lookups map[column]*lookupValidator
+	// customValidators is the map of custom validation rules registered by WithValidator.
+	// The key is the tag name.
+	customValidators map[string]ValidatorFunc
+	// checksums is the map of checksum algorithms registered by WithChecksum.
+	// The key is the algorithm name.
+	checksums map[string]ChecksumFunc
+	// numberFormat is the format of numbers set by WithNumberFormat.
+	// If it is nil, numbers are parsed by strconv as they are.
+	numberFormat *numberFormat
+	// fsys is the file system set by NewCSVFS. If it is nil, files are read from the OS file system.
+	fsys fs.FS
+	// closer is the file opened by NewCSVFS.
+	closer io.Closer
+	// beforeRowHook is the hook set by WithBeforeRow.
+	beforeRowHook func(line int, record []string) ([]string, error)
+	// afterRowHook is the hook set by WithAfterRow.
+	afterRowHook func(line int, v any) error
+	// concurrency is the number of files validated in parallel by ValidateFiles.
+	concurrency int
+	// lookupFiles is the cache of the values loaded by the in_file tag.
+	// The key is the in_file tag value without "in_file=". e.g. allowed_codes.csv:code
+	lookupFiles map[string][]string
+	// validationCache is the cache of the validation results set by WithValidationCache.
+	validationCache *validationCache
+	// autoDetectFormat is true if the delimiter and the header are detected by WithAutoDetect.
+	autoDetectFormat bool
+	// strictHeader is true if the duplicate header columns are errors. It is set by WithStrictHeader.
+	strictHeader bool
+	// duplicateHeaderFormat is the format of the renamed duplicate header columns.
+	// It is set by WithDuplicateHeaderFormat. The default is "%s_%d".
+	duplicateHeaderFormat string
+	// outputDelimiter is the delimiter of the CSV written by AnnotateTo. It is set by WithOutputDelimiter.
+	// If it is 0, the delimiter of the input is used.
+	outputDelimiter rune
+	// skipRows is the number of leading lines skipped before the header. It is set by WithSkipRows.
+	skipRows int
+	// naValues is the set of strings treated as a missing value. It is set by WithNAValues.
+	naValues map[string]struct{}
+	// skipRules is the set of the rule names disabled by WithSkipRules.
+	skipRules map[string]struct{}
+	// metricsHook is the hook set by WithMetrics.
+	metricsHook func(ctx context.Context, m Metrics)
+	// metrics collects the metrics of the current call. It is nil if WithMetrics is not set.
+	metrics *metricsCollector
+	// firstErrorPerCell is true if only the first error of each value is returned. It is set by WithFirstErrorPerCell.
+	firstErrorPerCell bool
+	// zeroCopyScan is true if the records are validated without keeping the structs. It is set by WithZeroCopyScan.
+	zeroCopyScan bool
+	// values is the buffer of the normalized values reused by decodeRecord if zeroCopyScan is true.
+	values []string
+	// perRowMaxErrors is the maximum number of detailed errors per line set by WithPerRowMaxErrors.
+	// If it is 0, all errors are returned.
+	perRowMaxErrors int
 	// i18nBundle is the i18n bundle. It is used to translate error messages.
 	// The default language is English.
 	i18nBundle *i18n.Bundle
 	// i18nLocalizer is the i18n localizer. It is used to localize error messages.
 	// The default language is English.
 	i18nLocalizer *i18n.Localizer
+	// secondaryLocalizer is the localizer of the second language set by WithBilingualErrors.
+	// It is nil if the error messages are monolingual.
+	secondaryLocalizer *i18n.Localizer
 }
 
 type (
@@ -50,7 +129,8 @@ type (
 // NewCSV returns a new CSV struct.
 func NewCSV(r io.Reader, opts ...Option) (*CSV, error) {
 	csv := &CSV{
-		reader: csv.NewReader(r),
+		reader:                csv.NewReader(r),
+		duplicateHeaderFormat: defaultDuplicateHeaderFormat,
 	}
 
 	if err := csv.newI18n(); err != nil {
@@ -62,9 +142,51 @@ func NewCSV(r io.Reader, opts ...Option) (*CSV, error) {
 			return nil, err
 		}
 	}
+
+	if csv.skipRows > 0 && r != nil {
+		var err error
+		if r, err = csv.skipLines(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if csv.autoDetectFormat && r != nil {
+		if err := csv.autoDetect(r); err != nil {
+			return nil, err
+		}
+	}
 	return csv, nil
 }
 
+// NewCSVFS returns a new CSV struct that reads the file from the file system.
+// It is useful for embedded files (embed.FS), zip archives and virtual file systems.
+// The paths of the in_file tag are also resolved in the file system.
+// The files with the .tsv or .tab extension are read with the tab delimiter unless WithSourceDelimiter is set.
+// The caller must call Close when the CSV is no longer used.
+func NewCSVFS(fsys fs.FS, name string, opts ...Option) (*CSV, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := NewCSV(f, append([]Option{delimiterByExtension(name)}, opts...)...)
+	if err != nil {
+		f.Close() //nolint:errcheck,gosec // the error of NewCSV is more important.
+		return nil, err
+	}
+	c.fsys = fsys
+	c.closer = f
+	return c, nil
+}
+
+// Close closes the file opened by NewCSVFS. It does nothing for the CSV created by NewCSV.
+func (c *CSV) Close() error {
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer.Close()
+}
+
 // newI18n initializes the i18n bundle and localizer.
 func (c *CSV) newI18n() error {
 	c.i18nBundle = i18n.NewBundle(language.English)
@@ -75,6 +197,9 @@ func (c *CSV) newI18n() error {
 			return NewError(c.i18nLocalizer, "ErrLoadMessageFile", err.Error())
 		}
 	}
+	if err := addErrorMessages(c.i18nBundle); err != nil {
+		return NewError(c.i18nLocalizer, "ErrLoadMessageFile", err.Error())
+	}
 	c.i18nLocalizer = i18n.NewLocalizer(c.i18nBundle, "en")
 	return nil
 }
@@ -82,62 +207,446 @@ func (c *CSV) newI18n() error {
 // Decode reads the CSV and returns the columns that have syntax errors on a per-line basis.
 // The strutSlicePointer is a pointer to structure slice where validation rules are set in struct tags.
 func (c *CSV) Decode(structSlicePointer any) []error {
-	errors := make([]error, 0)
-	if err := c.parseStructTag(structSlicePointer); err != nil {
-		errors = append(errors, err)
-		return errors
+	return c.DecodeContext(context.Background(), structSlicePointer)
+}
+
+// DecodeContext is the same as Decode, but it stops reading when ctx is canceled.
+// The context is checked before each record, and ctx.Err() is appended to the returned errors.
+// Custom validation rules can get the context by RowContext.Context to honor the cancellation.
+func (c *CSV) DecodeContext(ctx context.Context, structSlicePointer any) []error {
+	_, errors := c.decode(ctx, structSlicePointer, 0, nil, nil)
+	return errors
+}
+
+// Decode reads the CSV from r and returns the records as a slice of T.
+// T is a struct type, e.g. Decode[person](r). It is a shorthand for NewCSV and CSV.Decode,
+// and the returned errors are the same as CSV.Decode. If the options are invalid,
+// it returns nil and the error.
+func Decode[T any](r io.Reader, opts ...Option) ([]T, []error) {
+	c, err := NewCSV(r, opts...)
+	if err != nil {
+		return nil, []error{err}
 	}
 
-	firstLine := 1
-	if !c.headerless {
-		firstLine = 2 // first line is 2 because the header is on line 1.
-		if err := c.readHeader(); err != nil {
-			errors = append(errors, err)
-			return errors
-		}
+	records := make([]T, 0)
+	errs := c.Decode(&records)
+	return records, errs
+}
+
+// DecodeChunk reads at most n records of the CSV and appends them to the structure slice.
+// It returns the columns that have syntax errors on a per-line basis, in the same way as Decode.
+// The line number is preserved between calls, so a huge CSV can be processed in batches
+// (e.g. insert to DB, reset the slice, and call DecodeChunk again).
+// done is true when the end of the CSV has been reached or the CSV can no longer be read.
+func (c *CSV) DecodeChunk(structSlicePointer any, n int) (done bool, errs []error) {
+	if n <= 0 {
+		return true, []error{NewError(c.i18nLocalizer, ErrInvalidChunkSizeID, fmt.Sprintf("n=%d", n))}
+	}
+	return c.decode(context.Background(), structSlicePointer, n, nil, nil)
+}
+
+// ValidateSample reads the CSV and validates only every n-th record (the 1st, (n+1)-th, (2n+1)-th, ...).
+// It is a quick check of a huge CSV before a full pass. The structSlicePointer is only used to
+// get the validation rules, and the records are not appended to it. The hook set by WithAfterRow
+// is not called because no struct is returned.
+// The statistics of the col_* rules are computed from the sampled records.
+func (c *CSV) ValidateSample(structSlicePointer any, everyNth int) []error {
+	if everyNth <= 0 {
+		return []error{NewError(c.i18nLocalizer, ErrInvalidSampleIntervalID, fmt.Sprintf("n=%d", everyNth))}
+	}
+
+	_, errs := c.decode(context.Background(), structSlicePointer, 0, func(count int) bool {
+		return count%everyNth == 0
+	}, nil)
+	return errs
+}
+
+// recordHandler is called for each line read by decode, including the header.
+// record is the original values of the line and errs is the validation errors of the line.
+type recordHandler func(line int, record []string, errs []error) error
+
+// sampler reports whether the count-th record (the first record is 0) is validated.
+type sampler func(count int) bool
+
+// decode reads at most limit records of the CSV. If limit is 0, it reads all records.
+// If sample is not nil, only the sampled records are validated, and they are neither appended to
+// the slice nor passed to the WithAfterRow hook. If handler is not nil, it is called for each validated line.
+// It returns true when the end of the CSV has been reached or the CSV can no longer be read.
+func (c *CSV) decode(ctx context.Context, structSlicePointer any, limit int, sample sampler, handler recordHandler) (bool, []error) {
+	c.startMetrics()
+	done, errs := c.readRecords(ctx, structSlicePointer, limit, sample, handler)
+	c.localizeErrors(errs)
+	c.reportMetrics(ctx, errs)
+	return done, errs
+}
+
+// readRecords reads the records for decode.
+func (c *CSV) readRecords(ctx context.Context, structSlicePointer any, limit int, sample sampler, handler recordHandler) (bool, []error) {
+	errors := make([]error, 0)
+	if err := c.prepare(structSlicePointer, handler); err != nil {
+		errors = append(errors, err)
+		return true, errors
 	}
 
 	structSlicePtrValue := reflect.ValueOf(structSlicePointer)
 	structSliceValue := structSlicePtrValue.Elem()
+	headerNames := c.header.strings()
+
+	// keep is false if the structs are not appended to the slice.
+	keep := !c.zeroCopyScan && sample == nil
+	// scanValue is the struct reused for each record if the structs are not kept.
+	var scanValue reflect.Value
+	if !keep {
+		scanValue = reflect.New(structSliceValue.Type().Elem()).Elem()
+	}
+
+	for count := 0; limit == 0 || count < limit; count++ {
+		if err := ctx.Err(); err != nil {
+			errors = append(errors, err)
+			return true, errors
+		}
 
-	for line := firstLine; ; line++ {
-		record, err := c.reader.Read()
+		record, err := c.read()
 		if err == io.EOF {
-			break
+			errors = append(errors, c.checkColumnStats()...)
+			return true, errors
 		}
 		if err != nil {
 			errors = append(errors, err)
-			break
+			return true, errors
+		}
+
+		if sample != nil && !sample(count) {
+			c.line++
+			continue
+		}
+
+		c.metrics.addRow()
+		var rowErrs []error
+		if values, err := c.beforeRow(record); err != nil {
+			rowErrs = []error{err}
+		} else {
+			structValue := scanValue
+			if keep {
+				structValue = reflect.New(structSliceValue.Type().Elem()).Elem()
+			} else {
+				structValue.SetZero()
+			}
+			rowErrs = c.decodeRecord(ctx, structValue, values, headerNames)
+			if sample == nil {
+				if err := c.afterRow(structValue); err != nil {
+					rowErrs = append(rowErrs, err)
+				}
+			}
+			if keep {
+				structSliceValue.Set(reflect.Append(structSliceValue, structValue))
+			}
+		}
+		rowErrs = c.localizeErrors(c.limitRowErrors(rowErrs))
+		errors = append(errors, rowErrs...)
+
+		if handler != nil {
+			if err := handler(c.line, record, rowErrs); err != nil {
+				errors = append(errors, err)
+				return true, errors
+			}
+		}
+		c.line++
+	}
+	return false, errors
+}
+
+// limitRowErrors returns at most perRowMaxErrors errors of the line. If the errors exceed
+// the limit, the rest are summarized into one RowError without column.
+func (c *CSV) limitRowErrors(errs []error) []error {
+	if c.perRowMaxErrors == 0 || len(errs) <= c.perRowMaxErrors {
+		return errs
+	}
+	omitted := len(errs) - c.perRowMaxErrors
+	errs = append(errs[:c.perRowMaxErrors:c.perRowMaxErrors],
+		&RowError{Line: c.line, Err: NewError(c.i18nLocalizer, ErrMoreRowErrorsID, fmt.Sprintf("n=%d", omitted))})
+	return errs
+}
+
+// beforeRow calls the hook set by WithBeforeRow.
+// If the hook returns an error, it is returned as RowError without column.
+func (c *CSV) beforeRow(record []string) ([]string, error) {
+	if c.beforeRowHook == nil {
+		return record, nil
+	}
+
+	values, err := c.beforeRowHook(c.line, record)
+	if err != nil {
+		return nil, &RowError{Line: c.line, Err: err}
+	}
+	return values, nil
+}
+
+// afterRow calls the hook set by WithAfterRow with the pointer to the struct.
+// If the hook returns an error, it is returned as RowError without column.
+func (c *CSV) afterRow(structValue reflect.Value) error {
+	if c.afterRowHook == nil {
+		return nil
+	}
+
+	if err := c.afterRowHook(c.line, structValue.Addr().Interface()); err != nil {
+		return &RowError{Line: c.line, Err: err}
+	}
+	return nil
+}
+
+// prepare parses the struct tag and reads the header if it has not been read yet.
+// If handler is not nil, it is called for the header.
+func (c *CSV) prepare(structSlicePointer any, handler recordHandler) error {
+	if err := c.parseStructTag(structSlicePointer); err != nil {
+		return err
+	}
+
+	if c.line == 0 {
+		c.line = c.skipRows + 1
+		if !c.headerless {
+			record, err := c.readHeader()
+			if err != nil {
+				return err
+			}
+			if handler != nil {
+				if err := handler(c.line, record, nil); err != nil {
+					return err
+				}
+			}
+			c.line++ // the first record is on the next line of the header.
 		}
+	}
+	if !c.lookupsApplied {
+		if err := c.applyLookups(); err != nil {
+			return err
+		}
+		c.lookupsApplied = true
+	}
+	return nil
+}
+
+// decodeRecord validates the record and sets the values to the struct.
+// It returns the validation errors of the record.
+func (c *CSV) decodeRecord(ctx context.Context, structValue reflect.Value, record, headerNames []string) []error {
+	errors := make([]error, 0)
+
+	values := c.recordValues(len(record))
+	for i, v := range record {
+		values[i] = c.normalizeValue(i, v)
+	}
 
-		structValue := reflect.New(structSliceValue.Type().Elem()).Elem()
-		for i, v := range record {
-			validators := c.ruleSet[i]
-			for _, validator := range validators {
-				if err := validator.Do(c.i18nLocalizer, v); err != nil {
-					errors = append(errors, fmt.Errorf("line:%d column %s: %w", line, c.header[i], err))
+	rowCtx := RowContext{Line: c.line, Record: values, Header: headerNames, ctx: ctx}
+	for i, v := range values {
+		if i >= len(c.ruleSet) {
+			break // the struct has no field for the rest of the columns.
+		}
+		rowCtx.Column = c.columnName(i)
+		validators := c.ruleSet[i]
+		for j, validator := range validators {
+			if err := c.validate(validator, c.ruleKeys[i][j], v, rowCtx); err != nil {
+				c.metrics.addError(validator, err)
+				errors = append(errors, &RowError{Line: c.line, Column: rowCtx.Column, Err: err})
+				if c.firstErrorPerCell {
+					break
 				}
 			}
-			_ = setStructFieldValue(structValue, i, v) //nolint:errcheck // user will not see this error.
 		}
-		structSliceValue.Set(reflect.Append(structSliceValue, structValue))
+		if i < structValue.NumField() && isNumberKind(structValue.Field(i).Kind()) {
+			v = c.numberFormat.normalize(v)
+		}
+		if err := setStructFieldValue(structValue, i, v); err != nil {
+			continue // user will not see this error.
+		}
+		if err := validateField(structValue.Field(i)); err != nil {
+			errors = append(errors, &RowError{Line: c.line, Column: rowCtx.Column, Err: err})
+		}
 	}
 	return errors
 }
 
+// normalizeValue returns the value of the i-th column normalized by the normalize tag,
+// WithNAValues and the default tag.
+func (c *CSV) normalizeValue(i int, v string) string {
+	if i < len(c.normalizerSet) {
+		v = c.normalizerSet[i].apply(v)
+	}
+	if _, ok := c.naValues[v]; ok {
+		v = ""
+	}
+	if d, ok := c.defaults[i]; ok && v == "" {
+		v = d
+	}
+	return v
+}
+
+// recordValues returns the buffer for the n normalized values of a record.
+// The buffer is reused for each record if WithZeroCopyScan is set.
+func (c *CSV) recordValues(n int) []string {
+	if !c.zeroCopyScan {
+		return make([]string, n)
+	}
+	if cap(c.values) < n {
+		c.values = make([]string, n)
+	}
+	return c.values[:n]
+}
+
+// selfValidator is the interface for field types that validate themselves, e.g.
+//
+//	type Email string
+//	func (e Email) Validate() error { ... }
+type selfValidator interface {
+	Validate() error
+}
+
+// validateField calls the Validate method if the field type implements selfValidator
+// with a value or pointer receiver. It returns nil if the field type does not implement it.
+func validateField(field reflect.Value) error {
+	if !field.CanInterface() {
+		return nil
+	}
+	if field.CanAddr() {
+		if v, ok := field.Addr().Interface().(selfValidator); ok {
+			return v.Validate()
+		}
+	}
+	if v, ok := field.Interface().(selfValidator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// validate validates the value. If the validator needs the record that contains
+// the value, the record is also passed to the validator. The key identifies the rule
+// in the validation cache.
+func (c *CSV) validate(v validator, key, value string, ctx RowContext) error {
+	switch cv := v.(type) {
+	case crossFieldValidator:
+		return cv.DoWithRecord(c.i18nLocalizer, value, ctx.Record)
+	case *customValidator:
+		return c.localizeError(cv.DoWithContext(ctx, value))
+	}
+
+	if c.validationCache != nil && isCacheable(v) {
+		if err, ok := c.validationCache.get(key, value); ok {
+			return err
+		}
+		err := c.do(ctx.Context(), v, value)
+		if ctx.Context().Err() == nil {
+			c.validationCache.add(key, value, err) // the result of the canceled validation is not cached.
+		}
+		return err
+	}
+	return c.do(ctx.Context(), v, value)
+}
+
+// do validates the value. The value is normalized by WithNumberFormat if the validator
+// parses the value as a number.
+func (c *CSV) do(ctx context.Context, v validator, value string) error {
+	if isNumberValidator(v) {
+		value = c.numberFormat.normalize(value)
+	}
+	return doContext(ctx, v, c.i18nLocalizer, value)
+}
+
+// applyLookups adds the lookup validators specified by WithLookup to the ruleSet.
+// The name of WithLookup is the header name, or the column number (the first column is "1")
+// if the CSV has no header. It returns an error if the struct has no field for the column.
+func (c *CSV) applyLookups() error {
+	names := make([]string, 0, len(c.lookups))
+	for name := range c.lookups {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		i := c.columnIndex(name, len(c.ruleSet))
+		if i == -1 {
+			subMessage := withSuggestion(c.i18nLocalizer, fmt.Sprintf("column=%s", name), name, c.columnNames(len(c.ruleSet)))
+			return NewError(c.i18nLocalizer, ErrLookupColumnNotFoundID, subMessage)
+		}
+		c.ruleSet[i] = append(c.ruleSet[i], c.lookups[column(name)])
+		c.ruleKeys[i] = append(c.ruleKeys[i], "\x00lookup\x00"+name)
+	}
+	return nil
+}
+
 // readHeader reads the header of the CSV file.
-func (c *CSV) readHeader() error {
-	record, err := c.reader.Read()
+// It returns the original header record.
+func (c *CSV) readHeader() ([]string, error) {
+	record, err := c.read()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	columns := make([]column, 0, len(record))
 	for _, v := range record {
+		if alias, ok := c.headerAliases[v]; ok {
+			v = alias
+		}
 		columns = append(columns, column(v))
 	}
+
+	columns, err = c.renameDuplicateColumns(columns)
+	if err != nil {
+		return nil, err
+	}
 	c.header = columns
-	return nil
+	return record, nil
+}
+
+// renameDuplicateColumns renames the second and later columns of the same name with the format
+// set by WithDuplicateHeaderFormat (default "%s_%d"), e.g. amount, amount_2, amount_3.
+// If WithStrictHeader is set, it returns an error instead.
+func (c *CSV) renameDuplicateColumns(columns []column) ([]column, error) {
+	seen := make(map[column]int, len(columns))
+	for _, col := range columns {
+		seen[col] = 0
+	}
+
+	renamed := make([]column, 0, len(columns))
+	for i, col := range columns {
+		count := seen[col]
+		if count == 0 {
+			seen[col] = 1
+			renamed = append(renamed, col)
+			continue
+		}
+		if c.strictHeader {
+			return nil, NewError(c.i18nLocalizer, ErrDuplicateHeaderID, fmt.Sprintf("column=%s, index=%d", col, i+1))
+		}
+
+		name := col
+		for n := count + 1; ; n++ {
+			name = column(fmt.Sprintf(c.duplicateHeaderFormat, col, n))
+			if _, ok := seen[name]; !ok {
+				seen[col] = n
+				break
+			}
+		}
+		seen[name] = 1
+		renamed = append(renamed, name)
+	}
+	return renamed, nil
+}
+
+// columnName returns the header name of the column.
+// If the CSV has no header, it returns the column number (the first column is 1).
+func (c *CSV) columnName(index int) string {
+	if index < len(c.header) {
+		return string(c.header[index])
+	}
+	return strconv.Itoa(index + 1)
+}
+
+// strings returns the header as a string slice.
+func (h header) strings() []string {
+	names := make([]string, 0, len(h))
+	for _, v := range h {
+		names = append(names, string(v))
+	}
+	return names
 }
 
 // setStructFieldValue sets the value of a field in a struct.
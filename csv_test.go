@@ -2,10 +2,15 @@ package csv
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -231,427 +236,2131 @@ func TestCSV_Decode(t *testing.T) {
 	})
 }
 
-func Test_ErrCheck(t *testing.T) {
+func TestCSV_DecodeHeaderAliases(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rename localized header", func(t *testing.T) {
+		t.Parallel()
+
+		input := `顧客ID,氏名,国
+1,Gina,JP
+a,Yulia,FR
+`
+		c, err := NewCSV(
+			bytes.NewBufferString(input),
+			WithHeaderAliases(map[string]string{"顧客ID": "id", "氏名": "name", "国": "country"}),
+			WithLookup("country", []string{"JP", "RU"}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type customer struct {
+			ID      int    `validate:"numeric"`
+			Name    string `validate:"alpha"`
+			Country string
+		}
+		customers := make([]customer, 0)
+
+		errs := c.Decode(&customers)
+		want := []string{
+			"line:3 column id: target is not a numeric character: value=a",
+			"line:3 column country: target is not included in the lookup values: value=FR",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+}
+
+func TestCSV_DecodeHooks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("patch records before validation and enrich structs after decoding", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name,country
+1,Gina,JP
+2,Yulia,UK
+3,Denis,XX
+`
+		type person struct {
+			ID      int    `validate:"numeric"`
+			Name    string `validate:"alpha"`
+			Country string `validate:"oneof=JP GB RU"`
+			Line    int
+		}
+
+		before := func(_ int, record []string) ([]string, error) {
+			if record[2] == "XX" {
+				return nil, errors.New("unknown country code")
+			}
+			if record[2] == "UK" {
+				record[2] = "GB" // fix the known bad code.
+			}
+			return record, nil
+		}
+		after := func(line int, v any) error {
+			p, ok := v.(*person)
+			if !ok {
+				return fmt.Errorf("unexpected type %T", v)
+			}
+			p.Line = line
+			return nil
+		}
+
+		c, err := NewCSV(bytes.NewBufferString(input), WithBeforeRow(before), WithAfterRow(after))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+		if len(errs) != 1 || errs[0].Error() != "line:4: unknown country code" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+
+		want := []person{
+			{ID: 1, Name: "Gina", Country: "JP", Line: 2},
+			{ID: 2, Name: "Yulia", Country: "GB", Line: 3},
+		}
+		if diff := cmp.Diff(people, want); diff != "" {
+			t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+}
+
+func TestCSV_DecodeNumberFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse european number format", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id;price
+1.000;1.234,56
+2.000;0,5
+3.000;12.345,00
+`
+		c, err := NewCSV(bytes.NewBufferString(input), WithNumberFormat(',', '.'))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.reader.Comma = ';'
+
+		type item struct {
+			ID    int     `validate:"numeric"`
+			Price float64 `validate:"gt=1,lte=10000"`
+		}
+		items := make([]item, 0)
+
+		errs := c.Decode(&items)
+		want := []string{
+			"line:3 column price: target is not greater than the threshold value: threshold=1, value=0.5",
+			"line:4 column price: target is not less than or equal to the threshold value: threshold=10000, value=12345",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+
+		wantItems := []item{
+			{ID: 1000, Price: 1234.56},
+			{ID: 2000, Price: 0.5},
+			{ID: 3000, Price: 12345},
+		}
+		if diff := cmp.Diff(items, wantItems); diff != "" {
+			t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("should return an error if separators are the same", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCSV(bytes.NewBufferString(""), WithNumberFormat(',', ','))
+		if err == nil || err.Error() != "number format is invalid: decimal=',', thousands=','" {
+			t.Errorf("NewCSV() got error: %v", err)
+		}
+	})
+}
+
+func TestCSV_DecodeNAValues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("treat NA values as missing", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name,score
+1,Alice,NA
+2,N/A,80.5
+-,Carol,null
+`
+		c, err := NewCSV(bytes.NewBufferString(input), WithNAValues("NA", "N/A", "-", "null"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID    int    `validate:"required"`
+			Name  string `validate:"alpha"`
+			Score float64
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		want := []string{
+			"line:4 column id: target is required but is empty: value=",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+
+		wantPeople := []person{
+			{ID: 1, Name: "Alice"},
+			{ID: 2, Score: 80.5},
+			{Name: "Carol"},
+		}
+		if diff := cmp.Diff(people, wantPeople); diff != "" {
+			t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+}
+
+func TestCSV_DecodeValidationCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cache the validation results of repeated values", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,country,email
+1,JP,a@example.com
+2,XX,invalid
+3,JP,a@example.com
+4,XX,invalid
+`
+		c, err := NewCSV(bytes.NewBufferString(input), WithValidationCache(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID      int    `validate:"numeric"`
+			Country string `validate:"oneof=JP US"`
+			Email   string `validate:"email"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		want := []string{
+			"line:3 column country: target is not one of the values: oneof=JP US, value=XX",
+			"line:3 column email: target is not a valid email address: value=invalid",
+			"line:5 column country: target is not one of the values: oneof=JP US, value=XX",
+			"line:5 column email: target is not a valid email address: value=invalid",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("distinguish the same validator with the different thresholds", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("small,large\n7,7\n"), WithValidationCache(10))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type row struct {
+			Small int `validate:"lte=5"`
+			Large int `validate:"lte=10"`
+		}
+		rows := make([]row, 0)
+
+		errs := c.Decode(&rows)
+		want := "line:2 column small: target is not less than or equal to the threshold value: threshold=5, value=7"
+		if len(errs) != 1 || errs[0].Error() != want {
+			t.Errorf("CSV.Decode() got errors %v, want %q", errs, want)
+		}
+	})
+
+	t.Run("should return an error if size is not positive", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCSV(bytes.NewBufferString(""), WithValidationCache(0))
+		if err == nil || err.Error() != "validation cache size must be greater than 0: size=0" {
+			t.Errorf("NewCSV() got error: %v", err)
+		}
+	})
+}
+
+func TestCSV_DecodePerRowMaxErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("summarize the errors over the limit", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name,age,country
+1,Gina,23,JP
+a,1,x,XX
+`
+		c, err := NewCSV(bytes.NewBufferString(input), WithPerRowMaxErrors(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID      int    `validate:"numeric"`
+			Name    string `validate:"alpha"`
+			Age     int    `validate:"numeric"`
+			Country string `validate:"oneof=JP US"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		want := []string{
+			"line:3 column id: target is not a numeric character: value=a",
+			"line:3 column name: target is not an alphabetic character: value=1",
+			"line:3: more violations on this line are omitted: n=2",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+		if !errors.Is(errs[2], NewError(nil, ErrMoreRowErrorsID, "")) {
+			t.Errorf("CSV.Decode() got error %v, want ErrMoreRowErrors", errs[2])
+		}
+	})
+
+	t.Run("should return an error if k is not positive", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCSV(bytes.NewBufferString(""), WithPerRowMaxErrors(0))
+		if err == nil || err.Error() != "maximum number of errors per line must be greater than 0: k=0" {
+			t.Errorf("NewCSV() got error: %v", err)
+		}
+	})
+}
+
+func TestCSV_DecodeSkipRows(t *testing.T) {
+	t.Parallel()
+
+	t.Run("skip the banner and count the skipped lines", func(t *testing.T) {
+		t.Parallel()
+
+		input := `Sales report "2024"
+exported at 2024-06-01,by,admin,,
+id,name,age
+1,Gina,23
+a,Yulia,25
+`
+		c, err := NewCSV(bytes.NewBufferString(input), WithSkipRows(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"alpha"`
+			Age  int    `validate:"gt=24"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		want := []string{
+			"line:4 column age: target is not greater than the threshold value: threshold=24, value=23",
+			"line:5 column id: target is not a numeric character: value=a",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("skip the banner of the tsv without header", func(t *testing.T) {
+		t.Parallel()
+
+		input := "banner\n1\tGina\n2\t\n"
+		c, err := NewCSV(bytes.NewBufferString(input), WithTabDelimiter(), WithHeaderless(), WithSkipRows(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int
+			Name string `validate:"required"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		if len(errs) != 1 || errs[0].Error() != "line:3 column 2: target is required but is empty: value=" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+
+	t.Run("count the skipped lines in the parse errors", func(t *testing.T) {
+		t.Parallel()
+
+		type person struct {
+			ID   int
+			Name string
+		}
+
+		tests := []struct {
+			name  string
+			input string
+			skip  int
+			want  string
+		}{
+			{
+				name:  "quote error",
+				input: "banner\nbanner2\nid,name\n1,a\n2,\"b\n",
+				skip:  2,
+				want:  "parse error on line 5, column 6: extraneous or missing \" in quoted-field",
+			},
+			{
+				name:  "wrong number of fields",
+				input: "banner\nid,name\n1,a,extra\n",
+				skip:  1,
+				want:  "record on line 3: wrong number of fields",
+			},
+			{
+				name:  "quote error in the header",
+				input: "banner\nid,\"name\n",
+				skip:  1,
+				want:  "parse error on line 2, column 10: extraneous or missing \" in quoted-field",
+			},
+		}
+		for _, tt := range tests {
+			c, err := NewCSV(bytes.NewBufferString(tt.input), WithSkipRows(tt.skip))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			errs := c.Decode(&[]person{})
+			if len(errs) != 1 || errs[0].Error() != tt.want {
+				t.Errorf("%s: CSV.Decode() got errors %v, want %q", tt.name, errs, tt.want)
+			}
+		}
+	})
+
+	t.Run("should return an error if n is negative", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCSV(bytes.NewBufferString(""), WithSkipRows(-1))
+		if err == nil || err.Error() != "number of skipped lines must not be negative: n=-1" {
+			t.Errorf("NewCSV() got error: %v", err)
+		}
+	})
+}
+
+func TestCSV_DecodeBilingualErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("localize the error messages in two languages", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name
+,Gina
+2,Den1s
+`
+		c, err := NewCSV(bytes.NewBufferString(input), WithBilingualErrors("en", "ja"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   string `validate:"required"`
+			Name string `validate:"alpha"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		want := []string{
+			"line:2 column id: target is required but is empty / 必須パラメータが空です: value=",
+			"line:3 column name: target is not an alphabetic character / 値がアルファベット文字ではありません: value=Den1s",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("should return an error if the language is not supported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewCSV(bytes.NewBufferString(""), WithBilingualErrors("en", "fr"))
+		if err == nil || err.Error() != "language is not supported: language=fr" {
+			t.Errorf("NewCSV() got error: %v", err)
+		}
+	})
+}
+
+func TestCSV_DecodeSkipRules(t *testing.T) {
 	t.Parallel()
 
-	t.Run("error: `id,name,age,password` header", func(t *testing.T) {
+	t.Run("skip the rules and report them", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,email,code
+1,invalid,abc
+a,gina@example.com,xyz
+`
+		var skipped []string
+		hook := func(_ context.Context, m Metrics) {
+			skipped = m.SkippedRules
+		}
+		legacy := func(_ RowContext, _ string) error {
+			return errors.New("legacy code")
+		}
+
+		c, err := NewCSV(bytes.NewBufferString(input),
+			WithValidator("legacy", legacy), WithSkipRules("email", "legacy"), WithMetrics(hook))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type user struct {
+			ID    int    `validate:"numeric"`
+			Email string `validate:"required,email"`
+			Code  string `validate:"legacy"`
+		}
+		users := make([]user, 0)
+
+		errs := c.Decode(&users)
+		if len(errs) != 1 || errs[0].Error() != "line:3 column id: target is not a numeric character: value=a" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+		if diff := cmp.Diff(skipped, []string{"email", "legacy"}); diff != "" {
+			t.Errorf("Metrics.SkippedRules mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("should return an error if the name is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, name := range []string{"", "gt=1", "a,b"} {
+			if _, err := NewCSV(bytes.NewBufferString(""), WithSkipRules(name)); err == nil {
+				t.Errorf("NewCSV() with rule name %q got nil error", name)
+			}
+		}
+	})
+}
+
+func TestCSV_DecodeFirstErrorPerCell(t *testing.T) {
+	t.Parallel()
+
+	t.Run("return only the first error of each value", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,age
+a,x
+2,200
+`
+		c, err := NewCSV(bytes.NewBufferString(input), WithFirstErrorPerCell())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID  int `validate:"numeric,gt=0"`
+			Age int `validate:"numeric,gt=0,lt=150"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		want := []string{
+			"line:2 column id: target is not a numeric character: value=a",
+			"line:2 column age: target is not a numeric character: value=x",
+			"line:3 column age: target is not less than the threshold value: threshold=150, value=200",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+}
+
+func TestCSV_DecodeDefault(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID     int    `validate:"required"`
+		Stock  int    `default:"0" validate:"gte=0"`
+		Status string `default:"unknown" validate:"oneof=active unknown"`
+		Owner  string `default:"nobody" validate:"required"`
+	}
+	input := `id,stock,status,owner
+1,,,gina
+2,5,NA,
+3,-1,active,yulia
+`
+
+	c, err := NewCSV(bytes.NewBufferString(input), WithNAValues("NA"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := make([]item, 0)
+	errs := c.Decode(&items)
+	want := []string{
+		"line:3 column owner: target is required but is empty: value=",
+		"line:4 column stock: target is not greater than or equal to the threshold value: threshold=0, value=-1",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != want[i] {
+			t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+		}
+	}
+
+	wantItems := []item{
+		{ID: 1, Stock: 0, Status: "unknown", Owner: "gina"},
+		{ID: 2, Stock: 5, Status: "unknown", Owner: ""},
+		{ID: 3, Stock: -1, Status: "active", Owner: "yulia"},
+	}
+	if diff := cmp.Diff(items, wantItems); diff != "" {
+		t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestCSV_DecodeZeroCopyScan(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		ID   int    `validate:"numeric"`
+		Name string `validate:"alpha"`
+		Age  int    `validate:"gte=0"`
+	}
+	input := `id,name,age
+1,Gina,20
+a,Yulia1,-1
+3,Mai,
+`
+
+	c, err := NewCSV(bytes.NewBufferString(input), WithZeroCopyScan())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, 0)
+	hook := WithAfterRow(func(_ int, v any) error {
+		names = append(names, v.(*person).Name)
+		return nil
+	})
+	if err := hook(c); err != nil {
+		t.Fatal(err)
+	}
+
+	people := make([]person, 0)
+	errs := c.Decode(&people)
+	want := []string{
+		"line:3 column id: target is not a numeric character: value=a",
+		"line:3 column name: target is not an alphabetic character: value=Yulia1",
+		"line:3 column age: target is not greater than or equal to the threshold value: threshold=0, value=-1",
+		"line:4 column age: target is not greater than or equal to the threshold value: value=",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != want[i] {
+			t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+		}
+	}
+	if len(people) != 0 {
+		t.Errorf("CSV.Decode() got %d structs, want 0", len(people))
+	}
+	if diff := cmp.Diff(names, []string{"Gina", "Yulia1", "Mai"}); diff != "" {
+		t.Errorf("WithAfterRow() mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestCSV_DecodeDuplicateHeader(t *testing.T) {
+	t.Parallel()
+
+	type payment struct {
+		ID      int
+		Amount  int `validate:"gte=0"`
+		Amount2 int `validate:"gte=0"`
+		Amount3 int `validate:"gte=0"`
+		Amount4 int `validate:"gte=0"`
+	}
+	input := `id,amount,amount,amount_2,amount
+1,-1,-2,-3,-4
+`
+
+	t.Run("rename the duplicate columns", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payments := make([]payment, 0)
+		errs := c.Decode(&payments)
+		want := []string{
+			"line:2 column amount: target is not greater than or equal to the threshold value: threshold=0, value=-1",
+			"line:2 column amount_3: target is not greater than or equal to the threshold value: threshold=0, value=-2",
+			"line:2 column amount_2: target is not greater than or equal to the threshold value: threshold=0, value=-3",
+			"line:2 column amount_4: target is not greater than or equal to the threshold value: threshold=0, value=-4",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("rename the duplicate columns with the format", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id,amount,amount\n1,2,-3\n"), WithDuplicateHeaderFormat("%s(%d)"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payments := make([]payment, 0)
+		errs := c.Decode(&payments)
+		if len(errs) != 1 || errs[0].Error() != "line:2 column amount(2): target is not greater than or equal to the threshold value: threshold=0, value=-3" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return an error if the header is strict", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString(input), WithStrictHeader())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payments := make([]payment, 0)
+		errs := c.Decode(&payments)
+		if len(errs) != 1 || errs[0].Error() != "header column is duplicated: column=amount, index=3" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return an error if the format is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, format := range []string{"", "%s", "%d_%s", "%s_%d_%v"} {
+			if _, err := NewCSV(bytes.NewBufferString(""), WithDuplicateHeaderFormat(format)); err == nil {
+				t.Errorf("NewCSV() with format %q got nil error", format)
+			}
+		}
+	})
+}
+
+func TestCSV_DecodeNormalize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("normalize values before validation and assignment", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name,country
+ 1 ,  Gina ,jp
+2,YULIA , Ru
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID      int    `normalize:"trim" validate:"numeric"`
+			Name    string `normalize:"trim,lower" validate:"alpha"`
+			Country string `normalize:"trim,upper" validate:"oneof=JP RU"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		if len(errs) != 0 {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+
+		want := []person{
+			{ID: 1, Name: "gina", Country: "JP"},
+			{ID: 2, Name: "yulia", Country: "RU"},
+		}
+		if diff := cmp.Diff(people, want); diff != "" {
+			t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("should return an error if normalize tag value is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("name\nGina\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			Name string `normalize:"trim,title"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		if len(errs) != 1 || errs[0].Error() != "'normalize' tag value is invalid: title" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		Name string `validate:"alpha"`
+		Age  int    `validate:"gte=0"`
+	}
+
+	t.Run("decode to typed slice", func(t *testing.T) {
+		t.Parallel()
+
+		input := `name,age
+Alice,20
+Bob1,-1
+`
+		people, errs := Decode[person](bytes.NewBufferString(input))
+		want := []string{
+			"line:3 column name: target is not an alphabetic character: value=Bob1",
+			"line:3 column age: target is not greater than or equal to the threshold value: threshold=0, value=-1",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+
+		wantPeople := []person{{Name: "Alice", Age: 20}, {Name: "Bob1", Age: -1}}
+		if diff := cmp.Diff(people, wantPeople); diff != "" {
+			t.Errorf("Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("should return an error if the option is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		people, errs := Decode[person](bytes.NewBufferString("name,age\n"), WithNumberFormat(',', ','))
+		if people != nil || len(errs) != 1 {
+			t.Errorf("Decode() got %v, %v", people, errs)
+		}
+	})
+}
+
+func TestCSV_DecodeContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should stop reading if the context is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id\n1\n2\n3\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cancelAt2 := func(rowCtx RowContext, value string) error {
+			if value == "2" {
+				cancel()
+			}
+			return rowCtx.Context().Err()
+		}
+		if err := WithValidator("cancel_at_2", cancelAt2)(c); err != nil {
+			t.Fatal(err)
+		}
+
+		type row struct {
+			ID int `validate:"cancel_at_2"`
+		}
+		rows := make([]row, 0)
+
+		errs := c.DecodeContext(ctx, &rows)
+		want := []string{
+			"line:3 column id: context canceled",
+			"context canceled",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.DecodeContext() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.DecodeContext() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+		if len(rows) != 2 {
+			t.Errorf("CSV.DecodeContext() got %d rows, want 2", len(rows))
+		}
+		if !errors.Is(errs[len(errs)-1], context.Canceled) {
+			t.Errorf("CSV.DecodeContext() got error %v, want context.Canceled", errs[len(errs)-1])
+		}
+	})
+
+	t.Run("the lookup rules see the cancellation of the context", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id,country\n1,JP\n2,JP\n3,JP\n"), WithLookup("country", []string{"JP"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cancelAt2 := func(_ RowContext, value string) error {
+			if value == "2" {
+				cancel()
+			}
+			return nil
+		}
+		if err := WithValidator("cancel_at_2", cancelAt2)(c); err != nil {
+			t.Fatal(err)
+		}
+
+		type row struct {
+			ID      int `validate:"cancel_at_2"`
+			Country string
+		}
+		rows := make([]row, 0)
+
+		errs := c.DecodeContext(ctx, &rows)
+		want := []string{
+			"line:3 column country: context canceled",
+			"context canceled",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.DecodeContext() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.DecodeContext() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("RowContext.Context returns background context with Decode", func(t *testing.T) {
+		t.Parallel()
+
+		if (RowContext{}).Context() != context.Background() {
+			t.Error("RowContext.Context() should return context.Background()")
+		}
+	})
+}
+
+// testSKU is a field type that validates itself with a value receiver.
+type testSKU string
+
+func (s testSKU) Validate() error {
+	if !strings.HasPrefix(string(s), "SKU-") {
+		return fmt.Errorf("sku must start with SKU-: %s", string(s))
+	}
+	return nil
+}
+
+// testQuantity is a field type that validates itself with a pointer receiver.
+type testQuantity int
+
+func (q *testQuantity) Validate() error {
+	if *q%12 != 0 {
+		return fmt.Errorf("quantity must be a multiple of 12: %d", int(*q))
+	}
+	return nil
+}
+
+func TestCSV_DecodeSelfValidator(t *testing.T) {
+	t.Parallel()
+
+	input := `sku,quantity
+SKU-001,24
+002,10
+`
+	c, err := NewCSV(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type item struct {
+		SKU      testSKU      `validate:"required"`
+		Quantity testQuantity `validate:"gt=0"`
+	}
+	items := make([]item, 0)
+
+	errs := c.Decode(&items)
+	want := []string{
+		"line:3 column sku: sku must start with SKU-: 002",
+		"line:3 column quantity: quantity must be a multiple of 12: 10",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != want[i] {
+			t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+		}
+	}
+}
+
+func TestNewCSVFS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("read CSV and lookup file from file system", func(t *testing.T) {
+		t.Parallel()
+
+		fsys := fstest.MapFS{
+			"data/people.csv":  {Data: []byte("id,country\n1,JP\n2,FR\n")},
+			"data/country.csv": {Data: []byte("code\nJP\nRU\n")},
+		}
+
+		c, err := NewCSVFS(fsys, "data/people.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close() //nolint:errcheck
+
+		type person struct {
+			ID      int    `validate:"numeric"`
+			Country string `validate:"in_file=data/country.csv:code"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		if len(errs) != 1 || errs[0].Error() != "line:3 column country: target is not included in the lookup values: value=FR" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+
+		want := []person{{ID: 1, Country: "JP"}, {ID: 2, Country: "FR"}}
+		if diff := cmp.Diff(people, want); diff != "" {
+			t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("read TSV file by the extension", func(t *testing.T) {
+		t.Parallel()
+
+		fsys := fstest.MapFS{
+			"people.tsv": {Data: []byte("id\tcountry\n1\tJP\n")},
+			"people.tab": {Data: []byte("id;country\n2;RU\n")},
+		}
+
+		type person struct {
+			ID      int    `validate:"numeric"`
+			Country string `validate:"len=2"`
+		}
+		for name, opts := range map[string][]Option{
+			"people.tsv": nil,
+			"people.tab": {WithSourceDelimiter(';')},
+		} {
+			c, err := NewCSVFS(fsys, name, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close() //nolint:errcheck
+
+			people := make([]person, 0)
+			if errs := c.Decode(&people); len(errs) != 0 {
+				t.Errorf("CSV.Decode() got errors for %s: %v", name, errs)
+			}
+			if len(people) != 1 || len(people[0].Country) != 2 {
+				t.Errorf("CSV.Decode() got %v for %s", people, name)
+			}
+		}
+	})
+
+	t.Run("should return an error if the file does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewCSVFS(os.DirFS("testdata"), "not_exist.csv"); err == nil {
+			t.Error("NewCSVFS() got nil error")
+		}
+	})
+}
+
+func Test_ErrCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error: `id,name,age,password` header", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := os.Open(filepath.Join("testdata", "all_error.csv"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := NewCSV(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID       int    `validate:"numeric,gte=1"`
+			Name     string `validate:"alpha"`
+			Age      int    `validate:"numeric,gt=-1,lt=120,gte=0"`
+			Password string `validate:"required,alphanumeric"`
+			IsAdmin  bool   `validate:"boolean"`
+			Zero     int    `validate:"numeric,eq=0,lte=1,ne=1"`
+		}
+		people := make([]person, 0)
+
+		got := c.Decode(&people)
+		for i, err := range got {
+			switch i {
+			case 0:
+				if err.Error() != "line:2 column id: target is not greater than or equal to the threshold value: threshold=1, value=0" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 1:
+				if err.Error() != "line:3 column password: target is not an alphanumeric character: value=password-bad" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 2:
+				if err.Error() != "line:4 column password: target is required but is empty: value=" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 3:
+				if err.Error() != "line:5 column name: target is not an alphabetic character: value=1Joyless" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 4:
+				if err.Error() != "line:5 column zero: target is not equal to the threshold value: threshold=0, value=1" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 5:
+				if err.Error() != "line:5 column zero: target is equal to the threshold value: threshold=1, value=1" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 6:
+				if err.Error() != "line:6 column age: target is not less than the threshold value: threshold=120, value=120" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 7:
+				if err.Error() != "line:7 column is_admin: target is not a boolean: value=2" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 8:
+				if err.Error() != "line:8 column age: target is not greater than the threshold value: threshold=-1, value=-1" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 9:
+				if err.Error() != "line:8 column age: target is not greater than or equal to the threshold value: threshold=0, value=-1" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 10:
+				if err.Error() != "line:9 column id: target is not a numeric character: value=a" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate min, max: error case", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,age
+1,0
+2,-1
+3,120
+4,120.1
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID  int // no validate
+			Age int `validate:"min=0,max=120.0"`
+		}
+
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:3 column age: target is less than the minimum value: threshold=0, value=-1" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 1:
+				if err.Error() != "line:5 column age: target is greater than the maximum value: threshold=120, value=120.1" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate len: error case", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name
+1,abcd
+2,あいうえ
+3,👩‍❤‍💋‍👩🇷🇺😂🏯
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int    // no validate
+			Name string `validate:"len=3"`
+		}
+		persons := make([]person, 0)
+
+		errs := c.Decode(&persons)
+
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:2 column name: target length is not equal to the threshold value: length threshold=3, value=abcd" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 1:
+				if err.Error() != "line:3 column name: target length is not equal to the threshold value: length threshold=3, value=あいうえ" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 2:
+				if err.Error() != "line:4 column name: target length is not equal to the threshold value: length threshold=3, value=👩‍❤‍💋‍👩🇷🇺😂🏯" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate oneof: error case", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,gender
+1,smale
+2,child
+3,prefer_not_tooa
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID     int    // no validate
+			Gender string `validate:"oneof=male female prefer_not_to"`
+		}
+
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:2 column gender: target is not one of the values: oneof=male female prefer_not_to, value=smale (did you mean 'male'?)" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 1:
+				if err.Error() != "line:3 column gender: target is not one of the values: oneof=male female prefer_not_to, value=child" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 2:
+				if err.Error() != "line:4 column gender: target is not one of the values: oneof=male female prefer_not_to, value=prefer_not_tooa (did you mean 'prefer_not_to'?)" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate oneof and containsany with quoted values", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,city,note
+1,New York,sold out
+2,Tokyo,in stock
+3,Osaka,out
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type store struct {
+			ID   int
+			City string `validate:"oneof='New York' Tokyo"`
+			Note string `validate:"containsany='sold out' stock"`
+		}
+
+		stores := make([]store, 0)
+		errs := c.Decode(&stores)
+
+		want := []string{
+			"line:4 column city: target is not one of the values: oneof='New York' Tokyo, value=Osaka",
+			"line:4 column note: target does not contain any of the specified values: containsany='sold out' stock, value=out",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("validate lowercase", func(t *testing.T) {
+		t.Parallel()
+
+		input := `name
+Abc
+abc
+ABC
+あいう
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			Name string `validate:"lowercase"`
+		}
+
+		persons := make([]person, 0)
+		errs := c.Decode(&persons)
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:2 column name: target is not a lowercase character: value=Abc" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 1:
+				if err.Error() != "line:4 column name: target is not a lowercase character: value=ABC" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 2:
+				if err.Error() != "line:5 column name: target is not a lowercase character: value=あいう" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate uppercase", func(t *testing.T) {
+		t.Parallel()
+
+		input := `name
+Abc
+abc
+ABC
+あいう
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			Name string `validate:"uppercase"`
+		}
+
+		persons := make([]person, 0)
+		errs := c.Decode(&persons)
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:2 column name: target is not an uppercase character: value=Abc" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 1:
+				if err.Error() != "line:3 column name: target is not an uppercase character: value=abc" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 2:
+				if err.Error() != "line:5 column name: target is not an uppercase character: value=あいう" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate ascii", func(t *testing.T) {
+		t.Parallel()
+
+		input := fmt.Sprintf(
+			"name\n%s\n%s\n",
+			"\"!#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\`]^_abcdefghijklmnopqrstuvwxyz{|}~\"",
+			"あいう",
+		)
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		type ascii struct {
+			Name string `validate:"ascii"`
+		}
+
+		asciis := make([]ascii, 0)
+		errs := c.Decode(&asciis)
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:3 column name: target is not an ASCII character: value=あいう" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate email", func(t *testing.T) {
+		t.Parallel()
+
+		input := `email
+simple@example.com
+very.common@example.com
+disposable.style.email.with+symbol@example.com
+user.name+tag+sorting@example.com
+admin@mailserver1
+badあ@example.com
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type email struct {
+			Email string `validate:"email"`
+		}
+
+		emails := make([]email, 0)
+		errs := c.Decode(&emails)
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:6 column email: target is not a valid email address: value=admin@mailserver1" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			case 1:
+				if err.Error() != "line:7 column email: target is not a valid email address: value=badあ@example.com" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate contains", func(t *testing.T) {
+		t.Parallel()
+
+		input := `name
+search for a needle in a haystack
+example sentence
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type contains struct {
+			Name string `validate:"contains=needle"`
+		}
+
+		containsList := make([]contains, 0)
+		errs := c.Decode(&containsList)
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "line:3 column name: target does not contain the specified value: contains=needle, value=example sentence" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("invalid contains tag format", func(t *testing.T) {
 		t.Parallel()
 
-		f, err := os.Open(filepath.Join("testdata", "all_error.csv"))
+		input := `name
+search for a needle in a haystack
+example sentence
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		c, err := NewCSV(f)
+		type contains struct {
+			Name string `validate:"contains=needle bad_value"`
+		}
+
+		containsList := make([]contains, 0)
+		errs := c.Decode(&containsList)
+		for i, err := range errs {
+			switch i {
+			case 0:
+				if err.Error() != "'contains' tag format is invalid: contains=needle bad_value" {
+					t.Errorf("CSV.Decode() got errors: %v", err)
+				}
+			}
+		}
+	})
+
+	t.Run("validate containsany", func(t *testing.T) {
+		t.Parallel()
+
+		input := `name
+you can't find a needle in a haystack
+example sentence
+I sleep in a bed
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		type person struct {
-			ID       int    `validate:"numeric,gte=1"`
-			Name     string `validate:"alpha"`
-			Age      int    `validate:"numeric,gt=-1,lt=120,gte=0"`
-			Password string `validate:"required,alphanumeric"`
-			IsAdmin  bool   `validate:"boolean"`
-			Zero     int    `validate:"numeric,eq=0,lte=1,ne=1"`
+		type containsAny struct {
+			Name string `validate:"containsany=needle bed"`
 		}
-		people := make([]person, 0)
 
-		got := c.Decode(&people)
-		for i, err := range got {
+		containsAnyList := make([]containsAny, 0)
+		errs := c.Decode(&containsAnyList)
+		for i, err := range errs {
 			switch i {
 			case 0:
-				if err.Error() != "line:2 column id: target is not greater than or equal to the threshold value: threshold=1, value=0" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 1:
-				if err.Error() != "line:3 column password: target is not an alphanumeric character: value=password-bad" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 2:
-				if err.Error() != "line:4 column password: target is required but is empty: value=" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 3:
-				if err.Error() != "line:5 column name: target is not an alphabetic character: value=1Joyless" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 4:
-				if err.Error() != "line:5 column zero: target is not equal to the threshold value: threshold=0, value=1" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 5:
-				if err.Error() != "line:5 column zero: target is equal to the threshold value: threshold=1, value=1" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 6:
-				if err.Error() != "line:6 column age: target is not less than the threshold value: threshold=120, value=120" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 7:
-				if err.Error() != "line:7 column is_admin: target is not a boolean: value=2" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 8:
-				if err.Error() != "line:8 column age: target is not greater than the threshold value: threshold=-1, value=-1" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 9:
-				if err.Error() != "line:8 column age: target is not greater than or equal to the threshold value: threshold=0, value=-1" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 10:
-				if err.Error() != "line:9 column id: target is not a numeric character: value=a" {
+				if err.Error() != "line:3 column name: target does not contain any of the specified values: containsany=needle bed, value=example sentence" {
 					t.Errorf("CSV.Decode() got errors: %v", err)
 				}
 			}
 		}
 	})
 
-	t.Run("validate min, max: error case", func(t *testing.T) {
+	t.Run("validate html_encoded, xml, url_path", func(t *testing.T) {
 		t.Parallel()
 
-		input := `id,age
-1,0
-2,-1
-3,120
-4,120.1
+		input := `html,xml,path
+&lt;b&gt;bold&lt;/b&gt; &amp; &#39;quoted&#x27;,<a><b>text</b></a>,/users/john%20doe/profile
+<b>bold</b>,<a><b>text</a>,/users/john doe
+AT&T,<a/>,/users/%zz
+`
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type markup struct {
+			HTML string `validate:"html_encoded"`
+			XML  string `validate:"xml"`
+			Path string `validate:"url_path"`
+		}
+
+		markups := make([]markup, 0)
+		errs := c.Decode(&markups)
+
+		want := []string{
+			"line:3 column html: target is not an HTML encoded string: value=<b>bold</b>",
+			"line:3 column xml: target is not a well-formed XML: value=<a><b>text</a>",
+			"line:3 column path: target is not a valid URL path: value=/users/john doe",
+			"line:4 column html: target is not an HTML encoded string: value=AT&T",
+			"line:4 column path: target is not a valid URL path: value=/users/%zz",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("validate numeric_unicode, nowhitespace, singleline", func(t *testing.T) {
+		t.Parallel()
+
+		input := "code,user_id,comment\n" +
+			"１２３,gina,hello\n" +
+			"12a,gina yulia,\"hello\nworld\"\n" +
+			"٣4５,yulia\u3000,hello world\n"
+
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type record struct {
+			Code    string `validate:"numeric_unicode"`
+			UserID  string `validate:"nowhitespace"`
+			Comment string `validate:"singleline"`
+		}
+
+		records := make([]record, 0)
+		errs := c.Decode(&records)
+
+		want := []string{
+			"line:3 column code: target is not a unicode digit: value=12a",
+			"line:3 column user_id: target contains white space: value=gina yulia",
+			"line:3 column comment: target contains line breaks: value=\"hello\\nworld\"",
+			"line:4 column user_id: target contains white space: value=yulia\u3000",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("validate excluded_if, excluded_with", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,status,closed_reason,email,phone
+1,open,,gina@example.com,
+2,closed,duplicated,,
+3,open,not needed,,090-0000-0000
+4,closed,,yulia@example.com,090-0000-0000
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type ticket struct {
+			ID           int
+			Status       string
+			ClosedReason string `validate:"excluded_if=Status open"`
+			Email        string
+			Phone        string `validate:"excluded_with=Email"`
+		}
+
+		tickets := make([]ticket, 0)
+		errs := c.Decode(&tickets)
+
+		want := []string{
+			"line:4 column closed_reason: target must be empty because of the value of other field: excluded_if=Status open, value=not needed",
+			"line:5 column phone: target must be empty because other field is not empty: excluded_with=Email, value=090-0000-0000",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("validate gtfield, gtefield, ltfield, ltefield", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,start_date,end_date,min,max
+1,2024-01-01,2024-01-31,1,10
+2,2024-01-31,2024-01-01,10,9.5
+3,2024-01-01,2024-01-01,5,5
+4,2024-01-01,2024/02/01,,1
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type period struct {
+			ID        int
+			StartDate string `validate:"date"`
+			EndDate   string `validate:"date,gtfield=StartDate"`
+			Min       string
+			Max       string `validate:"gtefield=Min"`
+		}
+
+		periods := make([]period, 0)
+		errs := c.Decode(&periods)
+
+		want := []string{
+			"line:3 column end_date: target is not greater than the other field: gtfield=StartDate, StartDate=2024-01-31, value=2024-01-01",
+			"line:3 column max: target is not greater than or equal to the other field: gtefield=Min, Min=10, value=9.5",
+			"line:4 column end_date: target is not greater than the other field: gtfield=StartDate, StartDate=2024-01-01, value=2024-01-01",
+			"line:5 column end_date: target does not match the date or time layout: layout=2006-01-02, value=2024/02/01",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("should return an error if ltfield is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id,start,end\n1,a,b\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type period struct {
+			ID    int
+			Start string `validate:"ltfield="`
+			End   string
+		}
+
+		periods := make([]period, 0)
+		errs := c.Decode(&periods)
+		if len(errs) != 1 || errs[0].Error() != "'gtfield', 'gtefield', 'ltfield' or 'ltefield' tag format is invalid: ltfield=" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return an error if excluded_if refers to unknown field", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id,reason\n1,a\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type ticket struct {
+			ID     int
+			Reason string `validate:"excluded_if=State open"`
+		}
+
+		tickets := make([]ticket, 0)
+		errs := c.Decode(&tickets)
+		if len(errs) != 1 || errs[0].Error() != "field referred by the tag is not found: field=State" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should suggest the field name if excluded_if refers to misspelled field", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id,status,reason\n1,open,a\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type ticket struct {
+			ID     int
+			Status string
+			Reason string `validate:"excluded_if=Stauts open"`
+		}
+
+		tickets := make([]ticket, 0)
+		errs := c.Decode(&tickets)
+		if len(errs) != 1 || errs[0].Error() != "field referred by the tag is not found: field=Stauts (did you mean 'Status'?)" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+
+	t.Run("validate unique_items, min_items, max_items", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,tags,phones
+1,"go, csv",090-0000-0000|080-0000-0000
+2,"go,csv,go",090-0000-0000|090-0000-0000
+3,,090-0000-0000|080-0000-0000|070-0000-0000
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type record struct {
+			ID     int
+			Tags   string `validate:"unique_items,min_items=1"`
+			Phones string `validate:"item_sep=|,unique_items,max_items=2"`
+		}
+		records := make([]record, 0)
+
+		errs := c.Decode(&records)
+		want := []string{
+			"line:3 column tags: target has duplicate items: duplicate=go, value=go,csv,go",
+			"line:3 column phones: target has duplicate items: duplicate=090-0000-0000, value=090-0000-0000|090-0000-0000",
+			"line:4 column tags: target has fewer items than the minimum: threshold=1, items=0, value=",
+			"line:4 column phones: target has more items than the maximum: threshold=2, items=3, value=090-0000-0000|080-0000-0000|070-0000-0000",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+}
+
+func TestCSV_Lookup(t *testing.T) {
+	t.Parallel()
+
+	input := `id,country
+1,JP
+2,FR
+3,US
 `
+
+	t.Run("validate in_file", func(t *testing.T) {
+		t.Parallel()
+
 		c, err := NewCSV(bytes.NewBufferString(input))
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		type person struct {
-			ID  int // no validate
-			Age int `validate:"min=0,max=120.0"`
+			ID      int    // no validate
+			Country string `validate:"in_file=testdata/allowed_codes.csv:code"`
 		}
 
 		people := make([]person, 0)
 		errs := c.Decode(&people)
+		if len(errs) != 1 {
+			t.Fatalf("CSV.Decode() got errors: %v", errs)
+		}
+		if errs[0].Error() != "line:3 column country: target is not included in the lookup values: value=FR" {
+			t.Errorf("CSV.Decode() got errors: %v", errs[0])
+		}
+	})
 
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:3 column age: target is less than the minimum value: threshold=0, value=-1" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 1:
-				if err.Error() != "line:5 column age: target is greater than the maximum value: threshold=120, value=120.1" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+	t.Run("validate WithLookup", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString(input), WithLookup("country", []string{"JP", "FR"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID      int
+			Country string
+		}
+
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+		if len(errs) != 1 {
+			t.Fatalf("CSV.Decode() got errors: %v", errs)
+		}
+		if errs[0].Error() != "line:4 column country: target is not included in the lookup values: value=US" {
+			t.Errorf("CSV.Decode() got errors: %v", errs[0])
 		}
 	})
 
-	t.Run("validate len: error case", func(t *testing.T) {
+	t.Run("validate WithLookup by the column number if the CSV has no header", func(t *testing.T) {
 		t.Parallel()
 
-		input := `id,name
-1,abcd
-2,あいうえ
-3,👩‍❤‍💋‍👩🇷🇺😂🏯
-`
-		c, err := NewCSV(bytes.NewBufferString(input))
+		c, err := NewCSV(bytes.NewBufferString("1,JP\n2,US\n"), WithHeaderless(), WithLookup("2", []string{"JP", "FR"}))
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		type person struct {
-			ID   int    // no validate
-			Name string `validate:"len=3"`
+			ID      int
+			Country string
 		}
-		persons := make([]person, 0)
 
-		errs := c.Decode(&persons)
-
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:2 column name: target length is not equal to the threshold value: length threshold=3, value=abcd" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 1:
-				if err.Error() != "line:3 column name: target length is not equal to the threshold value: length threshold=3, value=あいうえ" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 2:
-				if err.Error() != "line:4 column name: target length is not equal to the threshold value: length threshold=3, value=👩‍❤‍💋‍👩🇷🇺😂🏯" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+		if len(errs) != 1 || errs[0].Error() != "line:2 column 2: target is not included in the lookup values: value=US" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
 		}
 	})
 
-	t.Run("validate oneof: error case", func(t *testing.T) {
+	t.Run("should return an error if the column of WithLookup is not found", func(t *testing.T) {
 		t.Parallel()
 
-		input := `id,gender
-1,smale
-2,child
-3,prefer_not_tooa
-`
-
-		c, err := NewCSV(bytes.NewBufferString(input))
+		c, err := NewCSV(bytes.NewBufferString(input), WithLookup("contry", []string{"JP", "FR"}))
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		type person struct {
-			ID     int    // no validate
-			Gender string `validate:"oneof=male female prefer_not_to"`
+			ID      int
+			Country string
 		}
 
 		people := make([]person, 0)
 		errs := c.Decode(&people)
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:2 column gender: target is not one of the values: oneof=male female prefer_not_to, value=smale" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 1:
-				if err.Error() != "line:3 column gender: target is not one of the values: oneof=male female prefer_not_to, value=child" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 2:
-				if err.Error() != "line:4 column gender: target is not one of the values: oneof=male female prefer_not_to, value=prefer_not_tooa" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+		if len(errs) != 1 || errs[0].Error() != "lookup column is not found: column=contry (did you mean 'country'?)" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
 		}
 	})
 
-	t.Run("validate lowercase", func(t *testing.T) {
+	t.Run("should return an error if the lookup column does not exist", func(t *testing.T) {
 		t.Parallel()
 
-		input := `name
-Abc
-abc
-ABC
-あいう
-`
-
 		c, err := NewCSV(bytes.NewBufferString(input))
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		type person struct {
-			Name string `validate:"lowercase"`
+			ID      int
+			Country string `validate:"in_file=testdata/allowed_codes.csv:name"`
 		}
 
-		persons := make([]person, 0)
-		errs := c.Decode(&persons)
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:2 column name: target is not a lowercase character: value=Abc" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 1:
-				if err.Error() != "line:4 column name: target is not a lowercase character: value=ABC" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 2:
-				if err.Error() != "line:5 column name: target is not a lowercase character: value=あいう" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+		if len(errs) != 1 {
+			t.Fatalf("CSV.Decode() got errors: %v", errs)
+		}
+		if errs[0].Error() != "failed to load lookup file: path=testdata/allowed_codes.csv: column name is not found" {
+			t.Errorf("CSV.Decode() got errors: %v", errs[0])
 		}
 	})
 
-	t.Run("validate uppercase", func(t *testing.T) {
+	t.Run("should return an error if in_file format is invalid", func(t *testing.T) {
 		t.Parallel()
 
-		input := `name
-Abc
-abc
-ABC
-あいう
-`
-
 		c, err := NewCSV(bytes.NewBufferString(input))
 		if err != nil {
 			t.Fatal(err)
 		}
 
 		type person struct {
-			Name string `validate:"uppercase"`
+			ID      int
+			Country string `validate:"in_file=testdata/allowed_codes.csv"`
 		}
 
-		persons := make([]person, 0)
-		errs := c.Decode(&persons)
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:2 column name: target is not an uppercase character: value=Abc" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 1:
-				if err.Error() != "line:3 column name: target is not an uppercase character: value=abc" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 2:
-				if err.Error() != "line:5 column name: target is not an uppercase character: value=あいう" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+		if len(errs) != 1 {
+			t.Fatalf("CSV.Decode() got errors: %v", errs)
+		}
+		if errs[0].Error() != "'in_file' tag format is invalid: in_file=testdata/allowed_codes.csv" {
+			t.Errorf("CSV.Decode() got errors: %v", errs[0])
 		}
 	})
+}
 
-	t.Run("validate ascii", func(t *testing.T) {
-		t.Parallel()
+func TestCSV_DecodeChunk(t *testing.T) {
+	t.Parallel()
 
-		input := fmt.Sprintf(
-			"name\n%s\n%s\n",
-			"\"!#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\`]^_abcdefghijklmnopqrstuvwxyz{|}~\"",
-			"あいう",
-		)
+	t.Run("read records in batches and preserve line number", func(t *testing.T) {
+		t.Parallel()
 
+		input := `id,name
+1,Gina
+2,Yulia
+3,Den1s
+4,Anna
+5,Ivan
+`
 		c, err := NewCSV(bytes.NewBufferString(input))
 		if err != nil {
 			t.Fatal(err)
 		}
-		type ascii struct {
-			Name string `validate:"ascii"`
+
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"alpha"`
 		}
 
-		asciis := make([]ascii, 0)
-		errs := c.Decode(&asciis)
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:3 column name: target is not an ASCII character: value=あいう" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
+		var got [][]person
+		var gotErrs []string
+		for {
+			people := make([]person, 0)
+			done, errs := c.DecodeChunk(&people, 2)
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			if len(people) != 0 {
+				got = append(got, people)
 			}
+			if done {
+				break
+			}
+		}
+
+		want := [][]person{
+			{{ID: 1, Name: "Gina"}, {ID: 2, Name: "Yulia"}},
+			{{ID: 3, Name: "Den1s"}, {ID: 4, Name: "Anna"}},
+			{{ID: 5, Name: "Ivan"}},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("CSV.DecodeChunk() mismatch (-got +want):\n%s", diff)
+		}
+
+		wantErrs := []string{"line:4 column name: target is not an alphabetic character: value=Den1s"}
+		if diff := cmp.Diff(gotErrs, wantErrs); diff != "" {
+			t.Errorf("CSV.DecodeChunk() errors mismatch (-got +want):\n%s", diff)
 		}
 	})
 
-	t.Run("validate email", func(t *testing.T) {
+	t.Run("should return an error if chunk size is not positive", func(t *testing.T) {
 		t.Parallel()
 
-		input := `email
-simple@example.com
-very.common@example.com
-disposable.style.email.with+symbol@example.com
-user.name+tag+sorting@example.com
-admin@mailserver1
-badあ@example.com
-`
-
-		c, err := NewCSV(bytes.NewBufferString(input))
+		c, err := NewCSV(bytes.NewBufferString("id\n1\n"))
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		type email struct {
-			Email string `validate:"email"`
+		type person struct {
+			ID int `validate:"numeric"`
 		}
+		people := make([]person, 0)
 
-		emails := make([]email, 0)
-		errs := c.Decode(&emails)
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:6 column email: target is not a valid email address: value=admin@mailserver1" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			case 1:
-				if err.Error() != "line:7 column email: target is not a valid email address: value=badあ@example.com" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+		done, errs := c.DecodeChunk(&people, 0)
+		if !done {
+			t.Errorf("CSV.DecodeChunk() done = %v, want true", done)
+		}
+		if len(errs) != 1 || errs[0].Error() != "chunk size must be greater than 0: n=0" {
+			t.Errorf("CSV.DecodeChunk() got errors: %v", errs)
 		}
 	})
+}
 
-	t.Run("validate contains", func(t *testing.T) {
+func TestCSV_ValidateSample(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validate every n-th record", func(t *testing.T) {
 		t.Parallel()
 
-		input := `name
-search for a needle in a haystack
-example sentence
+		input := `id,name
+a,Gina
+b,Yulia
+c,Denis
+d,Anna
+e,Ivan
 `
-
 		c, err := NewCSV(bytes.NewBufferString(input))
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		type contains struct {
-			Name string `validate:"contains=needle"`
+		type person struct {
+			ID   int `validate:"numeric"`
+			Name string
 		}
+		people := make([]person, 0)
 
-		containsList := make([]contains, 0)
-		errs := c.Decode(&containsList)
+		errs := c.ValidateSample(&people, 2)
+		want := []string{
+			"line:2 column id: target is not a numeric character: value=a",
+			"line:4 column id: target is not a numeric character: value=c",
+			"line:6 column id: target is not a numeric character: value=e",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.ValidateSample() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
 		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:3 column name: target does not contain the specified value: contains=needle, value=example sentence" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
+			if err.Error() != want[i] {
+				t.Errorf("CSV.ValidateSample() got error %q, want %q", err.Error(), want[i])
 			}
 		}
+		if len(people) != 0 {
+			t.Errorf("CSV.ValidateSample() appended records: %v", people)
+		}
 	})
 
-	t.Run("invalid contains tag format", func(t *testing.T) {
+	t.Run("should return an error if interval is not positive", func(t *testing.T) {
 		t.Parallel()
 
-		input := `name
-search for a needle in a haystack
-example sentence
-`
-
-		c, err := NewCSV(bytes.NewBufferString(input))
+		c, err := NewCSV(bytes.NewBufferString("id\n1\n"))
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		type contains struct {
-			Name string `validate:"contains=needle bad_value"`
+		type person struct {
+			ID int `validate:"numeric"`
 		}
 
-		containsList := make([]contains, 0)
-		errs := c.Decode(&containsList)
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "'contains' tag format is invalid: contains=needle bad_value" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+		errs := c.ValidateSample(&[]person{}, 0)
+		if len(errs) != 1 || errs[0].Error() != "sampling interval must be greater than 0: n=0" {
+			t.Errorf("CSV.ValidateSample() got errors: %v", errs)
 		}
 	})
+}
 
-	t.Run("validate containsany", func(t *testing.T) {
-		t.Parallel()
+func TestCSV_DecodeChunkFromPipe(t *testing.T) {
+	t.Parallel()
 
-		input := `name
-you can't find a needle in a haystack
-example sentence
-I sleep in a bed
-`
+	t.Run("decode records before the writer is closed", func(t *testing.T) {
+		t.Parallel()
 
-		c, err := NewCSV(bytes.NewBufferString(input))
+		pr, pw := io.Pipe()
+		c, err := NewCSV(pr)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		type containsAny struct {
-			Name string `validate:"containsany=needle bed"`
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"alpha"`
 		}
 
-		containsAnyList := make([]containsAny, 0)
-		errs := c.Decode(&containsAnyList)
-		for i, err := range errs {
-			switch i {
-			case 0:
-				if err.Error() != "line:3 column name: target does not contain any of the specified values: containsany=needle bed, value=example sentence" {
-					t.Errorf("CSV.Decode() got errors: %v", err)
-				}
-			}
+		go func() {
+			fmt.Fprint(pw, "id,name\n1,Gina\n2,Yulia\n") //nolint:errcheck
+		}()
+
+		people := make([]person, 0)
+		done, errs := c.DecodeChunk(&people, 2)
+		if done || len(errs) != 0 {
+			t.Fatalf("CSV.DecodeChunk() done=%v, errs=%v", done, errs)
+		}
+		if diff := cmp.Diff(people, []person{{ID: 1, Name: "Gina"}, {ID: 2, Name: "Yulia"}}); diff != "" {
+			t.Errorf("CSV.DecodeChunk() mismatch (-got +want):\n%s", diff)
 		}
-	})
 
+		go func() {
+			fmt.Fprint(pw, "3,Den1s\n") //nolint:errcheck
+			pw.Close()                  //nolint:errcheck
+		}()
+
+		people = people[:0]
+		done, errs = c.DecodeChunk(&people, 2)
+		if !done {
+			t.Errorf("CSV.DecodeChunk() done = %v, want true", done)
+		}
+		if len(errs) != 1 || errs[0].Error() != "line:4 column name: target is not an alphabetic character: value=Den1s" {
+			t.Errorf("CSV.DecodeChunk() got errors: %v", errs)
+		}
+	})
 }
@@ -0,0 +1,72 @@
+package csv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// RowContext is the context of the value passed to the custom validation rule.
+type RowContext struct {
+	// Line is the line number of the CSV. The first line is 1.
+	Line int
+	// Column is the header name of the column that contains the value.
+	Column string
+	// Record is all values of the line. The values must not be modified.
+	Record []string
+	// Header is the header of the CSV. It is empty if the CSV has no header.
+	Header []string
+	// ctx is the context passed to DecodeContext.
+	ctx context.Context
+}
+
+// Context returns the context passed to DecodeContext.
+// It returns context.Background() if the CSV is read by the other methods.
+func (r RowContext) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// Value returns the value of the column in the same line.
+// If the column does not exist, it returns false.
+func (r RowContext) Value(column string) (string, bool) {
+	for i, h := range r.Header {
+		if h == column && i < len(r.Record) {
+			return r.Record[i], true
+		}
+	}
+	return "", false
+}
+
+// ValidatorFunc is a custom validation rule. It returns an error if the value is invalid.
+// The error is returned from Decode as RowError.Err.
+type ValidatorFunc func(ctx RowContext, value string) error
+
+// customValidator is a struct that contains the custom validation rule.
+type customValidator struct {
+	name string
+	fn   ValidatorFunc
+}
+
+// newCustomValidator returns a new customValidator.
+func newCustomValidator(name string, fn ValidatorFunc) *customValidator {
+	return &customValidator{name: name, fn: fn}
+}
+
+// Do validates the target without the row context.
+func (c *customValidator) Do(_ *i18n.Localizer, target any) error {
+	return c.DoWithContext(RowContext{}, fmt.Sprintf("%v", target))
+}
+
+// DoContext validates the target with the context. The row context has only the context.
+func (c *customValidator) DoContext(ctx context.Context, _ *i18n.Localizer, target any) error {
+	return c.DoWithContext(RowContext{ctx: ctx}, fmt.Sprintf("%v", target))
+}
+
+// DoWithContext validates the target with the row context.
+func (c *customValidator) DoWithContext(ctx RowContext, target string) error {
+	return c.fn(ctx, target)
+}
@@ -0,0 +1,62 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithValidator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom validator can refer to sibling columns", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,start,end
+1,10,20
+2,30,20
+`
+		afterStart := func(ctx RowContext, value string) error {
+			start, ok := ctx.Value("start")
+			if !ok {
+				return errors.New("start column is not found")
+			}
+			if value <= start {
+				return fmt.Errorf("%s must be after start=%s at line %d, value=%s", ctx.Column, start, ctx.Line, value)
+			}
+			return nil
+		}
+
+		c, err := NewCSV(bytes.NewBufferString(input), WithValidator("after_start", afterStart))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type period struct {
+			ID    int
+			Start int `validate:"numeric"`
+			End   int `validate:"numeric,after_start"`
+		}
+		periods := make([]period, 0)
+
+		errs := c.Decode(&periods)
+		if len(errs) != 1 {
+			t.Fatalf("CSV.Decode() got errors: %v", errs)
+		}
+		if errs[0].Error() != "line:3 column end: end must be after start=30 at line 3, value=20" {
+			t.Errorf("CSV.Decode() got errors: %v", errs[0])
+		}
+	})
+
+	t.Run("should return an error if the name is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		fn := func(_ RowContext, _ string) error { return nil }
+		for _, name := range []string{"", "a,b", "a=b"} {
+			if _, err := NewCSV(bytes.NewBufferString(""), WithValidator(name, fn)); err == nil {
+				t.Errorf("NewCSV() with validator name %q got nil error", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// namedTimeLayouts is the layouts that can be specified by the name in the date, datetime and time tags.
+// e.g. `validate:"datetime=RFC3339"`. The layouts that contain "," can only be specified by the name
+// because "," is the separator of the rules.
+var namedTimeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// defaultTimeLayouts is the layouts used when the date, datetime and time tags have no layout.
+var defaultTimeLayouts = map[tagValue]string{
+	dateTagValue:     time.DateOnly,
+	dateTimeTagValue: time.RFC3339,
+	timeTagValue:     "15:04",
+}
+
+// parseTimeLayout returns the layout of the date, datetime or time tag.
+// tagValue is the value of the struct tag. e.g. date, date=2006/01/02, datetime=RFC3339
+func parseTimeLayout(t tagValue, value string) string {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return defaultTimeLayouts[t]
+	}
+	if layout, ok := namedTimeLayouts[parts[1]]; ok {
+		return layout
+	}
+	return parts[1]
+}
+
+// findTimeLayout returns the layout of the date, datetime or time tag in the tag list.
+// It returns false if the tag list has no such tag.
+func findTimeLayout(tagList []string) (string, bool) {
+	for _, t := range tagList {
+		if tv, ok := timeTagValueOf(t); ok {
+			return parseTimeLayout(tv, t), true
+		}
+	}
+	return "", false
+}
+
+// timeTagValueOf returns the date, datetime or time tag value of the tag.
+func timeTagValueOf(t string) (tagValue, bool) {
+	name := strings.SplitN(t, "=", 2)[0]
+	switch tagValue(name) {
+	case dateTagValue, dateTimeTagValue, timeTagValue:
+		return tagValue(name), true
+	}
+	return "", false
+}
+
+// timeValidator is a struct that contains the validation rules for a date, datetime or time column.
+type timeValidator struct {
+	layout string
+}
+
+// newTimeValidator returns a new timeValidator.
+func newTimeValidator(layout string) *timeValidator {
+	return &timeValidator{layout: layout}
+}
+
+// Do validates the target can be parsed with the layout. An empty target is valid.
+func (tv *timeValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrTimeFormatID, fmt.Sprintf("layout=%s, value=%v", tv.layout, target))
+	}
+
+	if v == "" {
+		return nil
+	}
+	if _, err := time.Parse(tv.layout, v); err != nil {
+		return NewError(localizer, ErrTimeFormatID, fmt.Sprintf("layout=%s, value=%v", tv.layout, target))
+	}
+	return nil
+}
+
+// timezoneValidator is a struct that contains the validation rules for the timezone of a date column.
+type timezoneValidator struct {
+	layout   string
+	location *time.Location
+}
+
+// newTimezoneValidator returns a new timezoneValidator.
+func newTimezoneValidator(layout string, location *time.Location) *timezoneValidator {
+	return &timezoneValidator{layout: layout, location: location}
+}
+
+// Do validates the UTC offset of the target is the same as the offset of the location.
+// If the target can not be parsed, it returns nil because the date tag reports the error.
+func (tv *timezoneValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrTimezoneID, fmt.Sprintf("timezone=%s, value=%v", tv.location, target))
+	}
+
+	t, err := time.ParseInLocation(tv.layout, v, tv.location)
+	if err != nil {
+		return nil
+	}
+	_, offset := t.Zone()
+	_, want := t.In(tv.location).Zone()
+	if offset != want {
+		return NewError(localizer, ErrTimezoneID, fmt.Sprintf("timezone=%s, value=%v", tv.location, target))
+	}
+	return nil
+}
+
+// parseBoundTime parses the bound of the before and after tags. The bound is parsed with the layout
+// of the field first, then with the DateOnly and RFC3339 layouts, e.g. before=2030-01-01 for a datetime field.
+func parseBoundTime(layout, value string) (time.Time, error) {
+	var err error
+	for _, l := range []string{layout, time.DateOnly, time.RFC3339} {
+		var t time.Time
+		if t, err = time.Parse(l, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// timeBoundValidator is a struct that contains the validation rules for the before and after tags.
+type timeBoundValidator struct {
+	tag    tagValue
+	layout string
+	bound  time.Time
+	// rule is the struct tag of the rule used in the error message. e.g. before=2030-01-01
+	rule string
+}
+
+// newTimeBoundValidator returns a new timeBoundValidator. The tag is beforeTagValue or afterTagValue.
+func newTimeBoundValidator(tag tagValue, layout string, bound time.Time, rule string) *timeBoundValidator {
+	return &timeBoundValidator{tag: tag, layout: layout, bound: bound, rule: rule}
+}
+
+// Do validates the target is before (or after) the bound. The bound itself is invalid.
+// If the target can not be parsed, it returns nil because the date tag reports the error.
+func (tv *timeBoundValidator) Do(localizer *i18n.Localizer, target any) error {
+	id := ErrBeforeID
+	if tv.tag == afterTagValue {
+		id = ErrAfterID
+	}
+
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, id, fmt.Sprintf("%s, value=%v", tv.rule, target))
+	}
+
+	t, err := time.Parse(tv.layout, v)
+	if err != nil {
+		return nil
+	}
+	if (tv.tag == beforeTagValue && !t.Before(tv.bound)) || (tv.tag == afterTagValue && !t.After(tv.bound)) {
+		return NewError(localizer, id, fmt.Sprintf("%s, value=%v", tv.rule, target))
+	}
+	return nil
+}
+
+// withinDaysValidator is a struct that contains the validation rules for the within_days tag.
+type withinDaysValidator struct {
+	layout string
+	days   int
+	now    func() time.Time
+}
+
+// newWithinDaysValidator returns a new withinDaysValidator.
+func newWithinDaysValidator(layout string, days int) *withinDaysValidator {
+	return &withinDaysValidator{layout: layout, days: days, now: time.Now}
+}
+
+// Do validates the target is within the days before or after the current time.
+// If the target can not be parsed, it returns nil because the date tag reports the error.
+func (wv *withinDaysValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrWithinDaysID, fmt.Sprintf("within_days=%d, value=%v", wv.days, target))
+	}
+
+	t, err := time.Parse(wv.layout, v)
+	if err != nil {
+		return nil
+	}
+	diff := wv.now().Sub(t)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > time.Duration(wv.days)*24*time.Hour {
+		return NewError(localizer, ErrWithinDaysID, fmt.Sprintf("within_days=%d, value=%v", wv.days, target))
+	}
+	return nil
+}
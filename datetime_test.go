@@ -0,0 +1,289 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/motemen/go-testutil/dataloc"
+)
+
+func Test_parseTimeLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tag  tagValue
+		arg  string
+		want string
+	}{
+		{name: "date without layout", tag: dateTagValue, arg: "date", want: time.DateOnly},
+		{name: "datetime without layout", tag: dateTimeTagValue, arg: "datetime", want: time.RFC3339},
+		{name: "time without layout", tag: timeTagValue, arg: "time", want: "15:04"},
+		{name: "named layout", tag: dateTimeTagValue, arg: "datetime=RFC1123Z", want: time.RFC1123Z},
+		{name: "layout with spaces", tag: dateTimeTagValue, arg: "datetime=2006/01/02 15:04", want: "2006/01/02 15:04"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := parseTimeLayout(tt.tag, tt.arg); got != tt.want {
+				t.Errorf("parseTimeLayout() = %q, want %q, test case at %s", got, tt.want, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_timeValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		layout  string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target matches the date layout", layout: time.DateOnly, arg: "2024-02-29", wantErr: false},
+		{name: "should return an error if the date does not exist", layout: time.DateOnly, arg: "2023-02-29", wantErr: true},
+		{name: "should return an error if target does not match the layout", layout: time.DateOnly, arg: "2024/02/29", wantErr: true},
+		{name: "should return nil if target matches RFC3339", layout: time.RFC3339, arg: "2024-02-29T10:00:00+09:00", wantErr: false},
+		{name: "should return nil if target matches the time layout", layout: "15:04", arg: "23:59", wantErr: false},
+		{name: "should return an error if the hour is out of range", layout: "15:04", arg: "24:00", wantErr: true},
+		{name: "should return nil if target is empty", layout: time.DateOnly, arg: "", wantErr: false},
+		{name: "should return an error if target is not a string", layout: time.DateOnly, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := newTimeValidator(tt.layout).Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("timeValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_timezoneValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		layout   string
+		location *time.Location
+		arg      any
+		wantErr  bool
+	}{
+		{name: "should return nil if target is UTC", layout: time.RFC3339, location: time.UTC, arg: "2024-01-01T00:00:00Z", wantErr: false},
+		{name: "should return nil if target has zero offset", layout: time.RFC3339, location: time.UTC, arg: "2024-01-01T00:00:00+00:00", wantErr: false},
+		{name: "should return an error if target has another offset", layout: time.RFC3339, location: time.UTC, arg: "2024-01-01T09:00:00+09:00", wantErr: true},
+		{name: "should return nil if target has the offset of the fixed zone", layout: time.RFC3339, location: time.FixedZone("JST", 9*60*60), arg: "2024-01-01T09:00:00+09:00", wantErr: false},
+		{name: "should return nil if the layout has no timezone", layout: time.DateOnly, location: time.UTC, arg: "2024-01-01", wantErr: false},
+		{name: "should return nil if target can not be parsed", layout: time.RFC3339, location: time.UTC, arg: "2024-01-01", wantErr: false},
+		{name: "should return an error if target is not a string", layout: time.RFC3339, location: time.UTC, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := newTimezoneValidator(tt.layout, tt.location).Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("timezoneValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_timeBoundValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	before := newTimeBoundValidator(beforeTagValue, time.DateOnly, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), "before=2030-01-01")
+	after := newTimeBoundValidator(afterTagValue, time.RFC3339, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "after=2000-01-01")
+	tests := []struct {
+		name      string
+		validator *timeBoundValidator
+		arg       any
+		wantErr   bool
+	}{
+		{name: "before: earlier date", validator: before, arg: "2029-12-31", wantErr: false},
+		{name: "before: same date", validator: before, arg: "2030-01-01", wantErr: true},
+		{name: "before: later date", validator: before, arg: "2031-01-01", wantErr: true},
+		{name: "after: later datetime", validator: after, arg: "2024-01-01T00:00:00Z", wantErr: false},
+		{name: "after: placeholder datetime", validator: after, arg: "1970-01-01T00:00:00Z", wantErr: true},
+		{name: "after: same instant in another offset", validator: after, arg: "2000-01-01T09:00:00+09:00", wantErr: true},
+		{name: "after: can not be parsed", validator: after, arg: "2024-01-01", wantErr: false},
+		{name: "after: not a string", validator: after, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.validator.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("timeBoundValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_withinDaysValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is within the days before now", arg: "2024-05-02", wantErr: false},
+		{name: "should return nil if target is within the days after now", arg: "2024-06-30", wantErr: false},
+		{name: "should return an error if target is too old", arg: "2024-04-30", wantErr: true},
+		{name: "should return an error if target is a placeholder", arg: "1970-01-01", wantErr: true},
+		{name: "should return nil if target can not be parsed", arg: "2024/05/02", wantErr: false},
+		{name: "should return an error if target is not a string", arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := newWithinDaysValidator(time.DateOnly, 30)
+			w.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+			if err := w.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("withinDaysValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func TestCSV_DecodeDateTime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validate date, datetime and time", func(t *testing.T) {
+		t.Parallel()
+
+		input := `day,created_at,opens_at,closed_on
+2024-02-29,2024-02-29T10:00:00Z,09:00,2024/02/29
+2023-02-29,2024-02-29T10:00:00+09:00,9am,29-02-2024
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type shop struct {
+			Day       string `validate:"date"`
+			CreatedAt string `validate:"datetime=RFC3339,timezone=UTC"`
+			OpensAt   string `validate:"time"`
+			ClosedOn  string `validate:"date=2006/01/02"`
+		}
+		shops := make([]shop, 0)
+
+		errs := c.Decode(&shops)
+		want := []string{
+			"line:3 column day: target does not match the date or time layout: layout=2006-01-02, value=2023-02-29",
+			"line:3 column created_at: target is not in the specified timezone: timezone=UTC, value=2024-02-29T10:00:00+09:00",
+			"line:3 column opens_at: target does not match the date or time layout: layout=15:04, value=9am",
+			"line:3 column closed_on: target does not match the date or time layout: layout=2006/01/02, value=29-02-2024",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("validate before and after", func(t *testing.T) {
+		t.Parallel()
+
+		input := `created_at,birthday
+2024-02-29T10:00:00Z,1990-01-01
+1970-01-01T00:00:00Z,2031-05-05
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type user struct {
+			CreatedAt string `validate:"datetime,after=2000-01-01"`
+			Birthday  string `validate:"date,before=2030-01-01"`
+		}
+		users := make([]user, 0)
+
+		errs := c.Decode(&users)
+		want := []string{
+			"line:3 column created_at: target is not after the specified time: after=2000-01-01, value=1970-01-01T00:00:00Z",
+			"line:3 column birthday: target is not before the specified time: before=2030-01-01, value=2031-05-05",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("should return an error if the time range tag is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		type noLayout struct {
+			CreatedAt string `validate:"before=2030-01-01"`
+		}
+		type invalidBound struct {
+			CreatedAt string `validate:"date,after=yesterday"`
+		}
+		type invalidDays struct {
+			CreatedAt string `validate:"date,within_days=a"`
+		}
+
+		for _, target := range []any{&[]noLayout{}, &[]invalidBound{}, &[]invalidDays{}} {
+			c, err := NewCSV(bytes.NewBufferString("created_at\n2024-01-01\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs := c.Decode(target)
+			if len(errs) != 1 || !errors.Is(errs[0], NewError(nil, ErrInvalidTimeRangeFormatID, "")) {
+				t.Errorf("CSV.Decode() got errors: %v", errs)
+			}
+		}
+	})
+
+	t.Run("should return an error if the timezone tag has no date layout", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("created_at\n2024-01-01T00:00:00Z\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type event struct {
+			CreatedAt string `validate:"timezone=UTC"`
+		}
+		events := make([]event, 0)
+
+		errs := c.Decode(&events)
+		if len(errs) != 1 || errs[0].Error() != "'timezone' tag format is invalid or the field has no date, datetime or time tag: timezone=UTC" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return an error if the timezone is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("created_at\n2024-01-01T00:00:00Z\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type event struct {
+			CreatedAt string `validate:"datetime,timezone=Mars/Olympus"`
+		}
+		events := make([]event, 0)
+
+		if errs := c.Decode(&events); len(errs) != 1 {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+	})
+}
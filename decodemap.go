@@ -0,0 +1,115 @@
+package csv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DecodeMapBy reads the CSV and stores the records in the map pointed by mapPointer,
+// keyed by the value of the specified column. mapPointer is a pointer to map[string]T
+// where T is a struct with validation rules, e.g. &map[string]country{}.
+// The key is the cell normalized by the normalize tag, not the decoded field, e.g. "007" and "7"
+// are the different keys. The first record of each key is stored, and the following records with
+// the same key are reported as RowError after the errors of Decode. The records whose key cell
+// has a validation error are not stored. It returns an error if WithZeroCopyScan is set because the structs are not kept.
+func (c *CSV) DecodeMapBy(keyColumn string, mapPointer any) []error {
+	if c.zeroCopyScan {
+		return []error{NewError(c.i18nLocalizer, ErrZeroCopyScanMapID, "")}
+	}
+
+	rv := reflect.ValueOf(mapPointer)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Map ||
+		rv.Elem().Type().Key().Kind() != reflect.String || rv.Elem().Type().Elem().Kind() != reflect.Struct {
+		return []error{NewError(c.i18nLocalizer, ErrMapPointerID, "")}
+	}
+
+	mapValue := rv.Elem()
+	if mapValue.IsNil() {
+		mapValue.Set(reflect.MakeMap(mapValue.Type()))
+	}
+	ctx := context.Background()
+	c.startMetrics()
+	// the duplicate keys are localized and counted in the metrics in the same way as the errors of Decode.
+	errs := c.localizeErrors(c.decodeMap(ctx, keyColumn, mapValue))
+	c.reportMetrics(ctx, errs)
+	return errs
+}
+
+// decodeMap decodes the records into the map for DecodeMapBy. The key is the cell of the key column
+// normalized in the same way as Decode, so "007" and "7" are the different keys even if the field is
+// a number. The records whose key cell has a validation error are not stored in the map.
+func (c *CSV) decodeMap(ctx context.Context, keyColumn string, mapValue reflect.Value) []error {
+	structSlicePtr := reflect.New(reflect.SliceOf(mapValue.Type().Elem()))
+	structSliceValue := structSlicePtr.Elem()
+	if err := c.prepare(structSlicePtr.Interface(), nil); err != nil {
+		return []error{err}
+	}
+	keyIndex := c.columnIndex(keyColumn, mapValue.Type().Elem().NumField())
+	if keyIndex == -1 {
+		subMessage := withSuggestion(c.i18nLocalizer, fmt.Sprintf("column=%s", keyColumn), keyColumn, c.columnNames(mapValue.Type().Elem().NumField()))
+		return []error{NewError(c.i18nLocalizer, ErrKeyColumnNotFoundID, subMessage)}
+	}
+
+	var (
+		decoded   = 0
+		firstLine = make(map[string]int)
+		dupErrs   = make([]error, 0)
+	)
+	_, errs := c.readRecords(ctx, structSlicePtr.Interface(), 0, nil, func(line int, record []string, rowErrs []error) error {
+		if structSliceValue.Len() == decoded {
+			return nil // the record that is not decoded by the WithBeforeRow hook.
+		}
+		decoded = structSliceValue.Len()
+		if keyIndex >= len(record) || hasColumnError(rowErrs, c.columnName(keyIndex)) {
+			return nil
+		}
+
+		item := structSliceValue.Index(decoded - 1)
+		key := c.normalizeValue(keyIndex, record[keyIndex])
+		if first, ok := firstLine[key]; ok {
+			err := NewError(c.i18nLocalizer, ErrDuplicateKeyID, fmt.Sprintf("key=%s, first line=%d", key, first))
+			c.metrics.addError(nil, err)
+			dupErrs = append(dupErrs, &RowError{Line: line, Column: keyColumn, Err: err})
+			return nil
+		}
+		firstLine[key] = line
+		mapValue.SetMapIndex(reflect.ValueOf(key).Convert(mapValue.Type().Key()), item)
+		return nil
+	})
+	return append(errs, dupErrs...)
+}
+
+// hasColumnError returns true if the errors have a RowError of the column.
+func hasColumnError(errs []error, column string) bool {
+	for _, err := range errs {
+		var rowErr *RowError
+		if errors.As(err, &rowErr) && rowErr.Column == column {
+			return true
+		}
+	}
+	return false
+}
+
+// columnIndex returns the index of the column that has the name. If the CSV has no header,
+// the name is the column number (the first column is "1"). It returns -1 if the column is not
+// found in the first numFields columns.
+func (c *CSV) columnIndex(name string, numFields int) int {
+	for i := 0; i < numFields; i++ {
+		if c.columnName(i) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnNames returns the names of the first numFields columns. If the CSV has no header,
+// the names are the column numbers.
+func (c *CSV) columnNames(numFields int) []string {
+	names := make([]string, 0, numFields)
+	for i := 0; i < numFields; i++ {
+		names = append(names, c.columnName(i))
+	}
+	return names
+}
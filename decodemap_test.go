@@ -0,0 +1,206 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCSV_DecodeMapBy(t *testing.T) {
+	t.Parallel()
+
+	type country struct {
+		Code string `validate:"len=2"`
+		Name string `validate:"required"`
+	}
+
+	t.Run("decode to map keyed by the column", func(t *testing.T) {
+		t.Parallel()
+
+		input := `code,name
+JP,Japan
+US,United States
+JP,Jamaica
+RUS,Russia
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		countries := make(map[string]country)
+		errs := c.DecodeMapBy("code", &countries)
+		want := []string{
+			"line:5 column code: target length is not equal to the threshold value: length threshold=2, value=RUS",
+			"line:4 column code: key is duplicated: key=JP, first line=2",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.DecodeMapBy() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.DecodeMapBy() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+
+		wantCountries := map[string]country{
+			"JP": {Code: "JP", Name: "Japan"},
+			"US": {Code: "US", Name: "United States"},
+		}
+		if diff := cmp.Diff(countries, wantCountries); diff != "" {
+			t.Errorf("CSV.DecodeMapBy() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("localize the duplicate keys and count them in the metrics", func(t *testing.T) {
+		t.Parallel()
+
+		var got Metrics
+		hook := func(_ context.Context, m Metrics) { got = m }
+		c, err := NewCSV(bytes.NewBufferString("code,name\nJP,Japan\nJP,Jamaica\n"),
+			WithBilingualErrors("en", "ja"), WithMetrics(hook))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		countries := make(map[string]country)
+		errs := c.DecodeMapBy("code", &countries)
+		want := "line:3 column code: key is duplicated / キーが重複しています: key=JP, first line=2"
+		if len(errs) != 1 || errs[0].Error() != want {
+			t.Errorf("CSV.DecodeMapBy() got errors %v, want %q", errs, want)
+		}
+		if got.Errors != 1 || got.ErrorsByRule[ErrDuplicateKeyID] != 1 {
+			t.Errorf("CSV.DecodeMapBy() got metrics %+v", got)
+		}
+	})
+
+	t.Run("use the column number if the CSV has no header", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("JP,Japan\nUS,United States\n"), WithHeaderless())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var countries map[string]country
+		if errs := c.DecodeMapBy("1", &countries); len(errs) != 0 {
+			t.Fatalf("CSV.DecodeMapBy() got errors: %v", errs)
+		}
+		if len(countries) != 2 || countries["US"].Name != "United States" {
+			t.Errorf("CSV.DecodeMapBy() got %v", countries)
+		}
+	})
+
+	t.Run("should return an error if the key column is not found", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("code,name\nJP,Japan\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		countries := make(map[string]country)
+		errs := c.DecodeMapBy("id", &countries)
+		if len(errs) != 1 || errs[0].Error() != "key column is not found: column=id" {
+			t.Errorf("CSV.DecodeMapBy() got errors: %v", errs)
+		}
+	})
+
+	t.Run("key by the cell instead of the decoded field", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id,name\n007,Bond\n7,Seven\nx,Unknown\ny,Nobody\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type agent struct {
+			ID   int `validate:"numeric"`
+			Name string
+		}
+		agents := make(map[string]agent)
+		errs := c.DecodeMapBy("id", &agents)
+		want := []string{
+			"line:4 column id: target is not a numeric character: value=x",
+			"line:5 column id: target is not a numeric character: value=y",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.DecodeMapBy() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.DecodeMapBy() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+
+		wantAgents := map[string]agent{
+			"007": {ID: 7, Name: "Bond"},
+			"7":   {ID: 7, Name: "Seven"},
+		}
+		if diff := cmp.Diff(agents, wantAgents); diff != "" {
+			t.Errorf("CSV.DecodeMapBy() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("should suggest the closest header if the key column is not found", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("code,name\nJP,Japan\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		countries := make(map[string]country)
+		errs := c.DecodeMapBy("cod", &countries)
+		if len(errs) != 1 || errs[0].Error() != "key column is not found: column=cod (did you mean 'code'?)" {
+			t.Errorf("CSV.DecodeMapBy() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return an error if the key column is not found in the CSV without records", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("code,name\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		countries := make(map[string]country)
+		errs := c.DecodeMapBy("id", &countries)
+		if len(errs) != 1 || errs[0].Error() != "key column is not found: column=id" {
+			t.Errorf("CSV.DecodeMapBy() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return an error if WithZeroCopyScan is set", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("code,name\nJP,Japan\n"), WithZeroCopyScan())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		countries := make(map[string]country)
+		errs := c.DecodeMapBy("code", &countries)
+		if len(errs) != 1 || errs[0].Error() != "DecodeMapBy can not be used with WithZeroCopyScan because the structs are not kept" {
+			t.Errorf("CSV.DecodeMapBy() got errors: %v", errs)
+		}
+	})
+
+	t.Run("should return an error if the value is not a pointer to a map", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("code,name\nJP,Japan\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		countries := make([]country, 0)
+		errs := c.DecodeMapBy("code", &countries)
+		if len(errs) != 1 || errs[0].Error() != "value is not a pointer to a map of struct keyed by string" {
+			t.Errorf("CSV.DecodeMapBy() got errors: %v", errs)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// DiffResult is the result of Diff. The rows are maps from the header name to the value.
+type DiffResult struct {
+	// Added is the rows that exist only in the new CSV, in the order of the new CSV.
+	Added []map[string]string
+	// Removed is the rows that exist only in the old CSV, in the order of the old CSV.
+	Removed []map[string]string
+	// Changed is the rows that have the same key but different values, in the order of the new CSV.
+	Changed []RowChange
+}
+
+// RowChange is a row that has the same key in the old and new CSV but different values.
+type RowChange struct {
+	// Key is the values of the key columns.
+	Key []string
+	// Old is the row in the old CSV.
+	Old map[string]string
+	// New is the row in the new CSV.
+	New map[string]string
+	// Columns is the header names of the changed columns, in the order of the new CSV header
+	// followed by the columns that exist only in the old CSV.
+	Columns []string
+}
+
+// diffTable is a CSV file read by Diff.
+type diffTable struct {
+	header []string
+	keys   []string
+	rows   map[string]map[string]string
+}
+
+// Diff compares the CSV files with headers and returns the added, removed and changed rows.
+// The rows are matched by the values of keyColumns (e.g. Diff("old.csv", "new.csv", "id")).
+// The files with the .tsv or .tab extension are read with the tab delimiter.
+// If keyColumns is empty, the whole row is the key, so a changed row is reported as removed and added.
+// It returns an error if a file can not be read, a key column does not exist or a key is duplicated.
+func Diff(oldPath, newPath string, keyColumns ...string) (*DiffResult, error) {
+	probe, err := NewCSV(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	oldTable, err := readDiffTable(probe.i18nLocalizer, oldPath, keyColumns)
+	if err != nil {
+		return nil, err
+	}
+	newTable, err := readDiffTable(probe.i18nLocalizer, newPath, keyColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := append([]string{}, newTable.header...)
+	for _, h := range oldTable.header {
+		if !contains(columns, h) {
+			columns = append(columns, h)
+		}
+	}
+
+	result := &DiffResult{
+		Added:   make([]map[string]string, 0),
+		Removed: make([]map[string]string, 0),
+		Changed: make([]RowChange, 0),
+	}
+	for _, key := range newTable.keys {
+		newRow := newTable.rows[key]
+		oldRow, ok := oldTable.rows[key]
+		if !ok {
+			result.Added = append(result.Added, newRow)
+			continue
+		}
+
+		changed := make([]string, 0)
+		for _, col := range columns {
+			if oldRow[col] != newRow[col] {
+				changed = append(changed, col)
+			}
+		}
+		if len(changed) > 0 {
+			result.Changed = append(result.Changed, RowChange{
+				Key:     keyValues(newRow, keyColumns),
+				Old:     oldRow,
+				New:     newRow,
+				Columns: changed,
+			})
+		}
+	}
+	for _, key := range oldTable.keys {
+		if _, ok := newTable.rows[key]; !ok {
+			result.Removed = append(result.Removed, oldTable.rows[key])
+		}
+	}
+	return result, nil
+}
+
+// readDiffTable reads the CSV file and indexes the rows by the key columns.
+func readDiffTable(localizer *i18n.Localizer, path string, keyColumns []string) (*diffTable, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read only.
+
+	reader := csv.NewReader(f)
+	if isTSV(path) {
+		reader.Comma = '\t'
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return &diffTable{rows: map[string]map[string]string{}}, nil
+	}
+
+	table := &diffTable{
+		header: records[0],
+		keys:   make([]string, 0, len(records)-1),
+		rows:   make(map[string]map[string]string, len(records)-1),
+	}
+	for _, col := range keyColumns {
+		if !contains(table.header, col) {
+			subMessage := withSuggestion(localizer, fmt.Sprintf("file=%s, column=%s", path, col), col, table.header)
+			return nil, NewError(localizer, ErrKeyColumnNotFoundID, subMessage)
+		}
+	}
+
+	for i, record := range records[1:] {
+		row := make(map[string]string, len(table.header))
+		for j, h := range table.header {
+			if j < len(record) {
+				row[h] = record[j]
+			}
+		}
+
+		key := strings.Join(record, "\x00")
+		if len(keyColumns) > 0 {
+			key = strings.Join(keyValues(row, keyColumns), "\x00")
+		}
+		if _, ok := table.rows[key]; ok {
+			if len(keyColumns) == 0 {
+				continue // the same row appears twice. It is not a difference.
+			}
+			return nil, NewError(localizer, ErrDuplicateKeyID,
+				fmt.Sprintf("file=%s, line=%d, key=%s", path, i+2, strings.Join(keyValues(row, keyColumns), " ")))
+		}
+		table.keys = append(table.keys, key)
+		table.rows[key] = row
+	}
+	return table, nil
+}
+
+// keyValues returns the values of the key columns of the row.
+func keyValues(row map[string]string, keyColumns []string) []string {
+	values := make([]string, 0, len(keyColumns))
+	for _, col := range keyColumns {
+		values = append(values, row[col])
+	}
+	return values
+}
+
+// contains returns true if the values contain the target.
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldPath := filepath.Join("testdata", "diff_old.csv")
+	newPath := filepath.Join("testdata", "diff_new.csv")
+
+	t.Run("compare by key column", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Diff(oldPath, newPath, "id")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &DiffResult{
+			Added:   []map[string]string{{"id": "4", "name": "Dave", "age": "50"}},
+			Removed: []map[string]string{{"id": "2", "name": "Bob", "age": "30"}},
+			Changed: []RowChange{
+				{
+					Key:     []string{"3"},
+					Old:     map[string]string{"id": "3", "name": "Carol", "age": "40"},
+					New:     map[string]string{"id": "3", "name": "Carol", "age": "41"},
+					Columns: []string{"age"},
+				},
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("Diff() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("compare whole rows without key columns", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := Diff(oldPath, newPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Added) != 2 || len(got.Removed) != 2 || len(got.Changed) != 0 {
+			t.Errorf("Diff() got %+v", got)
+		}
+	})
+
+	t.Run("should return an error if the key column does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Diff(oldPath, newPath, "email")
+		if err == nil || err.Error() != "key column is not found: file="+oldPath+", column=email" {
+			t.Errorf("Diff() got error: %v", err)
+		}
+	})
+
+	t.Run("should suggest the closest header if the key column does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Diff(oldPath, newPath, "nam")
+		if err == nil || err.Error() != "key column is not found: file="+oldPath+", column=nam (did you mean 'name'?)" {
+			t.Errorf("Diff() got error: %v", err)
+		}
+	})
+
+	t.Run("should return an error if the key is duplicated", func(t *testing.T) {
+		t.Parallel()
+
+		dupPath := filepath.Join("testdata", "diff_duplicate.csv")
+		_, err := Diff(oldPath, dupPath, "id")
+		if err == nil || err.Error() != "key is duplicated: file="+dupPath+", line=3, key=1" {
+			t.Errorf("Diff() got error: %v", err)
+		}
+	})
+
+	t.Run("should return an error if the file does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := Diff(oldPath, filepath.Join("testdata", "not_exist.csv"), "id"); err == nil {
+			t.Error("Diff() got nil error")
+		}
+	})
+}
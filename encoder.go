@@ -0,0 +1,106 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Encoder writes the structs of T to a CSV one by one, so a long-running job can stream
+// the decoded (and validated) structs to the output without building the whole slice first.
+// The writes are buffered; call Flush after the last row.
+type Encoder[T any] struct {
+	writer     *csv.Writer
+	structType reflect.Type
+}
+
+// NewEncoder returns a new Encoder that writes to w. T must be a struct whose fields are
+// string, int, uint or float types, the same types as Decode supports.
+// The opts are the same as NewCSV, e.g. WithOutputDelimiter to set the delimiter of the output.
+func NewEncoder[T any](w io.Writer, opts ...Option) (*Encoder[T], error) {
+	c, err := NewCSV(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidStructID, fmt.Sprintf("type=%v", structType))
+	}
+
+	writer := csv.NewWriter(w)
+	if c.outputDelimiter != 0 {
+		writer.Comma = c.outputDelimiter
+	}
+	return &Encoder[T]{writer: writer, structType: structType}, nil
+}
+
+// WriteHeader writes the header. The column names are the struct field names in snake case,
+// e.g. the field UserID is written as "user_id".
+func (e *Encoder[T]) WriteHeader() error {
+	header := make([]string, 0, e.structType.NumField())
+	for i := 0; i < e.structType.NumField(); i++ {
+		header = append(header, toSnakeCase(e.structType.Field(i).Name))
+	}
+	return e.writer.Write(header)
+}
+
+// WriteRow writes the fields of v as a record. It returns an error if v has a field of
+// the unsupported type.
+func (e *Encoder[T]) WriteRow(v T) error {
+	structValue := reflect.ValueOf(v)
+	record := make([]string, 0, structValue.NumField())
+	for i := 0; i < structValue.NumField(); i++ {
+		value, err := structFieldString(structValue.Field(i))
+		if err != nil {
+			return err
+		}
+		record = append(record, value)
+	}
+	return e.writer.Write(record)
+}
+
+// Flush writes the buffered rows to the underlying writer, and returns the error
+// that occurred during the previous WriteHeader, WriteRow or Flush.
+func (e *Encoder[T]) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// structFieldString returns the value of the field as a string. It is the reverse of setStructFieldValue.
+func structFieldString(fieldValue reflect.Value) (string, error) {
+	switch fieldValue.Kind() {
+	case reflect.String:
+		return fieldValue.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fieldValue.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fieldValue.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fieldValue.Float(), 'f', -1, fieldValue.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported field type: %s", fieldValue.Kind().String())
+	}
+}
+
+// toSnakeCase converts the struct field name to snake case. The acronyms are kept
+// together, e.g. "ID" is "id", "UserID" is "user_id" and "HTTPStatus" is "http_status".
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package csv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	type payment struct {
+		ID         int
+		UserID     string
+		HTTPStatus uint
+		Amount     float64
+	}
+
+	t.Run("should write the header and rows", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		enc, err := NewEncoder[payment](buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.WriteHeader(); err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range []payment{{1, "gina", 200, 10.5}, {2, "yulia, jr", 404, -3}} {
+			if err := enc.WriteRow(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "id,user_id,http_status,amount\n1,gina,200,10.5\n2,\"yulia, jr\",404,-3\n"
+		if got := buf.String(); got != want {
+			t.Errorf("Encoder got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("should write with the output delimiter", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		enc, err := NewEncoder[payment](buf, WithOutputDelimiter('\t'))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.WriteRow(payment{1, "gina", 200, 10.5}); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := buf.String(), "1\tgina\t200\t10.5\n"; got != want {
+			t.Errorf("Encoder got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("should return an error if the field type is unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		type flag struct {
+			Enabled bool
+		}
+
+		enc, err := NewEncoder[flag](&bytes.Buffer{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.WriteRow(flag{Enabled: true}); err == nil {
+			t.Error("Encoder.WriteRow() got nil error")
+		}
+	})
+
+	t.Run("should return an error if T is not a struct", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewEncoder[string](&bytes.Buffer{}, WithRussianLanguage())
+		if err == nil || err.Error() != "целевое значение не является структурой: type=string" {
+			t.Errorf("NewEncoder() got error: %v", err)
+		}
+	})
+}
+
+func Test_toSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"ID", "id"},
+		{"Name", "name"},
+		{"UserID", "user_id"},
+		{"HTTPStatus", "http_status"},
+		{"Address2Line", "address2_line"},
+	}
+	for _, tt := range tests {
+		if got := toSnakeCase(tt.name); got != tt.want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package csv
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
@@ -11,22 +12,26 @@ type Error struct {
 	id         string
 	subMessage string
 	localizer  *i18n.Localizer
+	// secondary is the localizer of the second language set by WithBilingualErrors.
+	secondary *i18n.Localizer
 }
 
 // Error returns the localized error message.
+// It returns the error ID instead of the message if the Error has no localizer.
+// If the Error has the secondary localizer, the messages of both languages are joined by " / ".
 func (e *Error) Error() string {
+	message := e.id
+	if e.localizer != nil {
+		message = localize(e.localizer, e.id, nil)
+		if e.secondary != nil {
+			message = fmt.Sprintf("%s / %s", message, localize(e.secondary, e.id, nil))
+		}
+	}
+
 	if e.subMessage != "" {
-		return fmt.Sprintf(
-			"%s: %s",
-			e.localizer.MustLocalize(&i18n.LocalizeConfig{
-				MessageID: e.id,
-			}),
-			e.subMessage,
-		)
+		return fmt.Sprintf("%s: %s", message, e.subMessage)
 	}
-	return e.localizer.MustLocalize(&i18n.LocalizeConfig{
-		MessageID: e.id,
-	})
+	return message
 }
 
 // Is reports whether the target error is the same as the error.
@@ -38,7 +43,8 @@ func (e *Error) Is(target error) bool {
 	return e.id == t.id
 }
 
-// NewError returns a new Error.
+// NewError returns a new Error. The localizer may be nil in a custom validation rule;
+// Decode then sets the localizer of the CSV. See RegisterErrorMessage.
 func NewError(localizer *i18n.Localizer, id, subMessage string) *Error {
 	return &Error{
 		id:         id,
@@ -47,6 +53,53 @@ func NewError(localizer *i18n.Localizer, id, subMessage string) *Error {
 	}
 }
 
+// RowError is an error that a value in the CSV violates the validation rule.
+// Decode returns RowError for "bad data values" and other errors for
+// "file is unreadable" (e.g. malformed quotes) or invalid struct tags.
+type RowError struct {
+	// Line is the line number of the CSV. The first line is 1.
+	// It is 0 if the error is related to the whole column (e.g. the error of col_mean_lte tag).
+	Line int
+	// Column is the header name of the column.
+	// It is empty if the error is not related to a column (e.g. the error returned by WithBeforeRow hook).
+	Column string
+	// Err is the validation error.
+	Err error
+}
+
+// Error returns the error message with the line number and the column name.
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line:%d: %v", e.Line, e.Err)
+	}
+	if e.Line == 0 {
+		return fmt.Sprintf("column %s: %v", e.Column, e.Err)
+	}
+	return fmt.Sprintf("line:%d column %s: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the validation error.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// SplitErrors splits the errors returned by Decode into the validation errors and the other error.
+// The other errors (e.g. I/O error, invalid struct tag) are joined into one error.
+// If there is no such error, the returned error is nil.
+func SplitErrors(errs []error) ([]*RowError, error) {
+	rowErrs := make([]*RowError, 0, len(errs))
+	others := make([]error, 0)
+	for _, err := range errs {
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		others = append(others, err)
+	}
+	return rowErrs, errors.Join(others...)
+}
+
 var (
 	// ErrStructSlicePointerID is the error ID used when the value is not a pointer to a struct slice.
 	ErrStructSlicePointerID = "ErrStructSlicePointer"
@@ -106,4 +159,146 @@ var (
 	ErrContainsAnyID = "ErrContainsAny"
 	// ErrInvalidContainsAnyFormatID is the error ID used when the contains any format is invalid.
 	ErrInvalidContainsAnyFormatID = "ErrInvalidContainsAnyFormat"
+	// ErrHTMLEncodedID is the error ID used when the target is not an HTML encoded string.
+	ErrHTMLEncodedID = "ErrHTMLEncoded"
+	// ErrXMLID is the error ID used when the target is not a well-formed XML.
+	ErrXMLID = "ErrXML"
+	// ErrURLPathID is the error ID used when the target is not a valid URL path.
+	ErrURLPathID = "ErrURLPath"
+	// ErrURLQueryID is the error ID used when the target is not a valid URL query string.
+	ErrURLQueryID = "ErrURLQuery"
+	// ErrDomainID is the error ID used when the target is not a valid domain name.
+	ErrDomainID = "ErrDomain"
+	// ErrInvalidChunkSizeID is the error ID used when the chunk size is not a positive number.
+	ErrInvalidChunkSizeID = "ErrInvalidChunkSize"
+	// ErrInvalidSampleIntervalID is the error ID used when the sampling interval is not a positive number.
+	ErrInvalidSampleIntervalID = "ErrInvalidSampleInterval"
+	// ErrInvalidConcurrencyID is the error ID used when the concurrency is not a positive number.
+	ErrInvalidConcurrencyID = "ErrInvalidConcurrency"
+	// ErrLookupID is the error ID used when the target is not included in the lookup values.
+	ErrLookupID = "ErrLookup"
+	// ErrInvalidInFileFormatID is the error ID used when the in_file format is invalid.
+	ErrInvalidInFileFormatID = "ErrInvalidInFileFormat"
+	// ErrLoadLookupFileID is the error ID used when the lookup file can not be loaded.
+	ErrLoadLookupFileID = "ErrLoadLookupFile"
+	// ErrInvalidNormalizeTagID is the error ID used when the normalize tag value is invalid.
+	ErrInvalidNormalizeTagID = "ErrInvalidNormalizeTag"
+	// ErrNumericUnicodeID is the error ID used when the target is not a unicode digit.
+	ErrNumericUnicodeID = "ErrNumericUnicode"
+	// ErrNoWhitespaceID is the error ID used when the target contains white space.
+	ErrNoWhitespaceID = "ErrNoWhitespace"
+	// ErrSingleLineID is the error ID used when the target contains line breaks.
+	ErrSingleLineID = "ErrSingleLine"
+	// ErrExcludedIfID is the error ID used when the target is not empty although other fields have the specified values.
+	ErrExcludedIfID = "ErrExcludedIf"
+	// ErrInvalidExcludedIfFormatID is the error ID used when the excluded_if format is invalid.
+	ErrInvalidExcludedIfFormatID = "ErrInvalidExcludedIfFormat"
+	// ErrExcludedWithID is the error ID used when the target is not empty although any of other fields is not empty.
+	ErrExcludedWithID = "ErrExcludedWith"
+	// ErrInvalidExcludedWithFormatID is the error ID used when the excluded_with format is invalid.
+	ErrInvalidExcludedWithFormatID = "ErrInvalidExcludedWithFormat"
+	// ErrFieldNotFoundID is the error ID used when the field referred by the tag is not found in the struct.
+	ErrFieldNotFoundID = "ErrFieldNotFound"
+	// ErrInvalidValidatorID is the error ID used when the custom validation rule is invalid.
+	ErrInvalidValidatorID = "ErrInvalidValidator"
+	// ErrInvalidNumberFormatID is the error ID used when the number format is invalid.
+	ErrInvalidNumberFormatID = "ErrInvalidNumberFormat"
+	// ErrChecksumID is the error ID used when the check digits of the target are invalid.
+	ErrChecksumID = "ErrChecksum"
+	// ErrInvalidChecksumFormatID is the error ID used when the checksum format is invalid or the algorithm is unknown.
+	ErrInvalidChecksumFormatID = "ErrInvalidChecksumFormat"
+	// ErrUniqueItemsID is the error ID used when the target has duplicate items.
+	ErrUniqueItemsID = "ErrUniqueItems"
+	// ErrMinItemsID is the error ID used when the target has fewer items than the minimum.
+	ErrMinItemsID = "ErrMinItems"
+	// ErrMaxItemsID is the error ID used when the target has more items than the maximum.
+	ErrMaxItemsID = "ErrMaxItems"
+	// ErrAlphaUnicodeSpaceID is the error ID used when the target is not unicode letters and spaces.
+	ErrAlphaUnicodeSpaceID = "ErrAlphaUnicodeSpace"
+	// ErrPersonNameID is the error ID used when the target is not a person name.
+	ErrPersonNameID = "ErrPersonName"
+	// ErrIDFormatID is the error ID used when the target does not match the identifier format.
+	ErrIDFormatID = "ErrIDFormat"
+	// ErrInvalidIDFormatID is the error ID used when the id_format format is invalid.
+	ErrInvalidIDFormatID = "ErrInvalidIDFormat"
+	// ErrTimeFormatID is the error ID used when the target does not match the date or time layout.
+	ErrTimeFormatID = "ErrTimeFormat"
+	// ErrTimezoneID is the error ID used when the UTC offset of the target is not the same as the specified timezone.
+	ErrTimezoneID = "ErrTimezone"
+	// ErrInvalidTimezoneFormatID is the error ID used when the timezone format is invalid.
+	ErrInvalidTimezoneFormatID = "ErrInvalidTimezoneFormat"
+	// ErrBeforeID is the error ID used when the target is not before the time of the before tag.
+	ErrBeforeID = "ErrBefore"
+	// ErrAfterID is the error ID used when the target is not after the time of the after tag.
+	ErrAfterID = "ErrAfter"
+	// ErrWithinDaysID is the error ID used when the target is not within the days of the within_days tag from now.
+	ErrWithinDaysID = "ErrWithinDays"
+	// ErrInvalidTimeRangeFormatID is the error ID used when the before, after or within_days tag format is invalid.
+	ErrInvalidTimeRangeFormatID = "ErrInvalidTimeRangeFormat"
+	// ErrMinLengthID is the error ID used when the target length is less than the minimum length.
+	ErrMinLengthID = "ErrMinLength"
+	// ErrMaxLengthID is the error ID used when the target length is greater than the maximum length.
+	ErrMaxLengthID = "ErrMaxLength"
+	// ErrColumnMeanID is the error ID used when the mean of the column does not satisfy the threshold.
+	ErrColumnMeanID = "ErrColumnMean"
+	// ErrColumnStddevID is the error ID used when the standard deviation of the column does not satisfy the threshold.
+	ErrColumnStddevID = "ErrColumnStddev"
+	// ErrDigitsID is the error ID used when the target is not the specified number of digits.
+	ErrDigitsID = "ErrDigits"
+	// ErrInvalidDigitsFormatID is the error ID used when the digits or numeric_len format is invalid.
+	ErrInvalidDigitsFormatID = "ErrInvalidDigitsFormat"
+	// ErrZenkakuID is the error ID used when the target contains a character that is not full-width.
+	ErrZenkakuID = "ErrZenkaku"
+	// ErrHankakuID is the error ID used when the target contains a character that is not half-width.
+	ErrHankakuID = "ErrHankaku"
+	// ErrKatakanaID is the error ID used when the target contains a character that is not katakana.
+	ErrKatakanaID = "ErrKatakana"
+	// ErrHiraganaID is the error ID used when the target contains a character that is not hiragana.
+	ErrHiraganaID = "ErrHiragana"
+	// ErrHashID is the error ID used when the target is not a hex string of the hash digest.
+	ErrHashID = "ErrHash"
+	// ErrIPClassID is the error ID used when the target is not an IP address of the class (private, public or loopback).
+	ErrIPClassID = "ErrIPClass"
+	// ErrMapPointerID is the error ID used when the value is not a pointer to a map of struct keyed by string.
+	ErrMapPointerID = "ErrMapPointer"
+	// ErrKeyColumnNotFoundID is the error ID used when the key column of DecodeMapBy is not found.
+	ErrKeyColumnNotFoundID = "ErrKeyColumnNotFound"
+	// ErrLookupColumnNotFoundID is the error ID used when the column of WithLookup is not found.
+	ErrLookupColumnNotFoundID = "ErrLookupColumnNotFound"
+	// ErrDuplicateKeyID is the error ID used when the key of DecodeMapBy is duplicated.
+	ErrDuplicateKeyID = "ErrDuplicateKey"
+	// ErrZeroCopyScanMapID is the error ID used when DecodeMapBy is called with WithZeroCopyScan.
+	ErrZeroCopyScanMapID = "ErrZeroCopyScanMap"
+	// ErrInvalidCacheSizeID is the error ID used when the size of the validation cache is invalid.
+	ErrInvalidCacheSizeID = "ErrInvalidCacheSize"
+	// ErrInvalidPerRowMaxErrorsID is the error ID used when the maximum number of errors per line is invalid.
+	ErrInvalidPerRowMaxErrorsID = "ErrInvalidPerRowMaxErrors"
+	// ErrMoreRowErrorsID is the error ID used when the errors of a line exceed WithPerRowMaxErrors.
+	ErrMoreRowErrorsID = "ErrMoreRowErrors"
+	// ErrInvalidSkipRowsID is the error ID used when the number of skipped lines is invalid.
+	ErrInvalidSkipRowsID = "ErrInvalidSkipRows"
+	// ErrUnknownRuleID is the error ID used when the validate tag has an unknown rule.
+	ErrUnknownRuleID = "ErrUnknownRule"
+	// ErrContradictoryRulesID is the error ID used when no value satisfies the rules of the field.
+	ErrContradictoryRulesID = "ErrContradictoryRules"
+	// ErrUnsupportedLanguageID is the error ID used when the language of the error messages is not supported.
+	ErrUnsupportedLanguageID = "ErrUnsupportedLanguage"
+	// ErrInvalidSkipRuleID is the error ID used when the rule name of WithSkipRules is invalid.
+	ErrInvalidSkipRuleID = "ErrInvalidSkipRule"
+	// ErrInvalidDelimiterID is the error ID used when the delimiter of WithOutputDelimiter is invalid.
+	ErrInvalidDelimiterID = "ErrInvalidDelimiter"
+	// ErrDuplicateHeaderID is the error ID used when the header has duplicate columns and WithStrictHeader is set.
+	ErrDuplicateHeaderID = "ErrDuplicateHeader"
+	// ErrInvalidDuplicateHeaderFormatID is the error ID used when the format of WithDuplicateHeaderFormat is invalid.
+	ErrInvalidDuplicateHeaderFormatID = "ErrInvalidDuplicateHeaderFormat"
+	// ErrGreaterThanFieldID is the error ID used when the target is not greater than other field.
+	ErrGreaterThanFieldID = "ErrGreaterThanField"
+	// ErrGreaterThanEqualFieldID is the error ID used when the target is not greater than or equal to other field.
+	ErrGreaterThanEqualFieldID = "ErrGreaterThanEqualField"
+	// ErrLessThanFieldID is the error ID used when the target is not less than other field.
+	ErrLessThanFieldID = "ErrLessThanField"
+	// ErrLessThanEqualFieldID is the error ID used when the target is not less than or equal to other field.
+	ErrLessThanEqualFieldID = "ErrLessThanEqualField"
+	// ErrInvalidFieldComparisonFormatID is the error ID used when the gtfield, gtefield, ltfield or ltefield format is invalid.
+	ErrInvalidFieldComparisonFormatID = "ErrInvalidFieldComparisonFormat"
 )
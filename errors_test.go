@@ -1,7 +1,13 @@
 package csv
 
 import (
+	"bytes"
+	"encoding/csv"
+	"errors"
 	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
 )
 
 func TestError_Error(t *testing.T) {
@@ -34,6 +40,38 @@ func TestError_Error(t *testing.T) {
 	})
 }
 
+func TestError_ErrorFallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the English message if the language has no translation", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString(""), WithJapaneseLanguage())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.i18nBundle.AddMessages(language.English, &i18n.Message{ID: "ErrOnlyEnglish", Other: "only in English"}); err != nil {
+			t.Fatal(err)
+		}
+
+		got := NewError(c.i18nLocalizer, "ErrOnlyEnglish", "value=a").Error()
+		want := "only in English: value=a"
+		if got != want {
+			t.Errorf("Error() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("should return the error ID if the message is not found", func(t *testing.T) {
+		t.Parallel()
+
+		got := NewError(helperLocalizer(t), "ErrNotRegistered", "value=a").Error()
+		want := "ErrNotRegistered: value=a"
+		if got != want {
+			t.Errorf("Error() = %v, want %v", got, want)
+		}
+	})
+}
+
 func TestError_Is(t *testing.T) {
 	t.Parallel()
 
@@ -65,3 +103,59 @@ func TestError_Is(t *testing.T) {
 		}
 	})
 }
+
+func TestSplitErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should split validation errors and read error", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name
+1,Gina
+a,Yulia
+3,"Den"is
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"alpha"`
+		}
+		people := make([]person, 0)
+
+		rowErrs, err := SplitErrors(c.Decode(&people))
+		if len(rowErrs) != 1 {
+			t.Fatalf("SplitErrors() got row errors: %v", rowErrs)
+		}
+		if rowErrs[0].Line != 3 || rowErrs[0].Column != "id" {
+			t.Errorf("SplitErrors() got line=%d column=%s, want line=3 column=id", rowErrs[0].Line, rowErrs[0].Column)
+		}
+		if !errors.Is(rowErrs[0], NewError(helperLocalizer(t), ErrInvalidNumericID, "")) {
+			t.Errorf("SplitErrors() got %v, want ErrInvalidNumeric", rowErrs[0])
+		}
+
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("SplitErrors() got error %v, want *csv.ParseError", err)
+		}
+	})
+
+	t.Run("should return nil error if there are only validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		rowErr := &RowError{Line: 2, Column: "id", Err: NewError(helperLocalizer(t), ErrRequiredID, "value=")}
+		rowErrs, err := SplitErrors([]error{rowErr})
+		if err != nil {
+			t.Errorf("SplitErrors() got error %v, want nil", err)
+		}
+		if len(rowErrs) != 1 || rowErrs[0] != rowErr {
+			t.Errorf("SplitErrors() got row errors: %v", rowErrs)
+		}
+		if rowErr.Error() != "line:2 column id: target is required but is empty: value=" {
+			t.Errorf("RowError.Error() = %v", rowErr.Error())
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ValidateFiles validates the files with the rules set in the struct tags of T.
+// T is a struct type, e.g. ValidateFiles[person](paths). The same options are applied to each file.
+// It returns the errors per file path. A file without errors has an empty slice.
+// The errors that a file can not be opened are also included in the file's errors.
+// It returns an error only if the options are invalid.
+// The files with the .tsv or .tab extension are read with the tab delimiter unless WithSourceDelimiter is set.
+// By default, the files are validated one by one. Use WithConcurrency to validate them in parallel.
+// The structs are not kept, so the WithAfterRow hook is not called. The WithBeforeRow and WithMetrics
+// hooks are called for each file, concurrently if WithConcurrency is greater than 1.
+func ValidateFiles[T any](paths []string, opts ...Option) (map[string][]error, error) {
+	probe, err := NewCSV(nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := probe.concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, concurrency)
+		result = make(map[string][]error, len(paths))
+	)
+	for _, path := range paths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(path string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			errs := validateFile[T](path, opts...)
+			mu.Lock()
+			result[path] = errs
+			mu.Unlock()
+		}(path)
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// delimiterByExtension returns the Option that sets the tab delimiter if the file has the .tsv or .tab
+// extension. It is applied before the options of the user, so WithSourceDelimiter overrides it.
+func delimiterByExtension(name string) Option {
+	return func(c *CSV) error {
+		if isTSV(name) {
+			c.reader.Comma = '\t'
+		}
+		return nil
+	}
+}
+
+// isTSV returns true if the file has the .tsv or .tab extension.
+func isTSV(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".tsv", ".tab":
+		return true
+	}
+	return false
+}
+
+// validateFile validates the file with the rules set in the struct tags of T.
+func validateFile[T any](path string, opts ...Option) []error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return []error{err}
+	}
+	defer f.Close() //nolint:errcheck // read only.
+
+	c, err := NewCSV(f, append([]Option{delimiterByExtension(path)}, opts...)...)
+	if err != nil {
+		return []error{err}
+	}
+	return c.ValidateSample(&[]T{}, 1)
+}
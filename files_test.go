@@ -0,0 +1,94 @@
+package csv
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestValidateFiles(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		ID   int    `validate:"numeric,gte=1"`
+		Name string `validate:"alpha"`
+		Age  int    `validate:"numeric"`
+	}
+
+	sample := filepath.Join("testdata", "sample.csv")
+	allError := filepath.Join("testdata", "all_error.csv")
+	notExist := filepath.Join("testdata", "not_exist.csv")
+
+	for _, concurrency := range []int{1, 3} {
+		concurrency := concurrency
+		t.Run("validate files", func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ValidateFiles[person]([]string{sample, allError, notExist}, WithConcurrency(concurrency))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != 3 {
+				t.Fatalf("ValidateFiles() got %d files, want 3", len(got))
+			}
+			if len(got[sample]) != 0 {
+				t.Errorf("ValidateFiles() got errors for %s: %v", sample, got[sample])
+			}
+			if len(got[allError]) != 4 {
+				t.Errorf("ValidateFiles() got errors for %s: %v", allError, got[allError])
+			}
+			if len(got[notExist]) != 1 {
+				t.Errorf("ValidateFiles() got errors for %s: %v", notExist, got[notExist])
+			}
+		})
+	}
+
+	t.Run("validate TSV files by the extension", func(t *testing.T) {
+		t.Parallel()
+
+		tsv := filepath.Join("testdata", "sample.tsv")
+		got, err := ValidateFiles[person]([]string{tsv})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got[tsv]) != 0 {
+			t.Errorf("ValidateFiles() got errors for %s: %v", tsv, got[tsv])
+		}
+
+		got, err = ValidateFiles[person]([]string{tsv}, WithSourceDelimiter(','))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got[tsv]) == 0 {
+			t.Errorf("ValidateFiles() with WithSourceDelimiter got no errors for %s", tsv)
+		}
+	})
+
+	t.Run("call the metrics hook per file without the after row hook", func(t *testing.T) {
+		t.Parallel()
+
+		var metricsCalls, afterRowCalls int32
+		metrics := func(_ context.Context, _ Metrics) { atomic.AddInt32(&metricsCalls, 1) }
+		afterRow := func(_ int, _ any) error {
+			atomic.AddInt32(&afterRowCalls, 1)
+			return nil
+		}
+		_, err := ValidateFiles[person]([]string{sample, allError}, WithConcurrency(2), WithMetrics(metrics), WithAfterRow(afterRow))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if metricsCalls != 2 || afterRowCalls != 0 {
+			t.Errorf("ValidateFiles() called the metrics hook %d times and the after row hook %d times", metricsCalls, afterRowCalls)
+		}
+	})
+
+	t.Run("should return an error if options are invalid", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := ValidateFiles[person]([]string{sample}, WithConcurrency(0)); err == nil {
+			t.Error("ValidateFiles() got nil error")
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var (
+	// errorMessagesMu protects errorMessages.
+	errorMessagesMu sync.RWMutex
+	// errorMessages is the map of the error messages registered by RegisterErrorMessage.
+	// The key is the error ID, and the value is the map of the language tag and the translation.
+	errorMessages = make(map[string]map[language.Tag]string)
+)
+
+// RegisterErrorMessage registers the translations of the error ID so that the custom
+// validation rules can return localized errors. The key of translations is the language
+// tag (e.g. "en", "ja", "ru"), and the English translation is required because it is used
+// when the other language is not translated.
+//
+// The messages are added to the CSV created by NewCSV after the registration. A custom rule
+// returns NewError(nil, id, subMessage), and Decode localizes it in the language of the CSV.
+// If the id is already registered or is a built-in error ID, its translations are replaced.
+func RegisterErrorMessage(id string, translations map[string]string) error {
+	if id == "" {
+		return fmt.Errorf("error ID is empty")
+	}
+	if translations["en"] == "" {
+		return fmt.Errorf("translation for \"en\" is required: id=%s", id)
+	}
+
+	messages := make(map[language.Tag]string, len(translations))
+	for lang, text := range translations {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return fmt.Errorf("invalid language tag: id=%s, lang=%s: %w", id, lang, err)
+		}
+		messages[tag] = text
+	}
+
+	errorMessagesMu.Lock()
+	defer errorMessagesMu.Unlock()
+	errorMessages[id] = messages
+	return nil
+}
+
+// addErrorMessages adds the error messages registered by RegisterErrorMessage to the bundle.
+// The English translation is added to the languages of the bundle that have no translation.
+func addErrorMessages(bundle *i18n.Bundle) error {
+	errorMessagesMu.RLock()
+	defer errorMessagesMu.RUnlock()
+
+	for id, messages := range errorMessages {
+		for _, tag := range bundle.LanguageTags() {
+			if _, ok := messages[tag]; !ok {
+				if err := bundle.AddMessages(tag, &i18n.Message{ID: id, Other: messages[language.English]}); err != nil {
+					return err
+				}
+			}
+		}
+		for tag, text := range messages {
+			if err := bundle.AddMessages(tag, &i18n.Message{ID: id, Other: text}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// localize returns the message of the id in the language of the localizer.
+// If the language has no translation, it returns the English message, and if
+// the message is not found in any language, it returns the id instead of panicking.
+func localize(localizer *i18n.Localizer, id string, data any) string {
+	message, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:    id,
+		TemplateData: data,
+	})
+	if err != nil && message == "" {
+		return id
+	}
+	return message
+}
+
+// isSupportedLanguage returns true if the bundle has the messages of the language, e.g. "en", "ja", "ru".
+func (c *CSV) isSupportedLanguage(lang string) bool {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return false
+	}
+	for _, t := range c.i18nBundle.LanguageTags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// localizeError returns the error localized by the localizers of the CSV if the error is or wraps
+// an Error created by NewError(nil, ...) in the custom rule, or the second language is set by
+// WithBilingualErrors. The original Error is not modified because it may be a sentinel error
+// shared by the CSVs of other languages or used concurrently.
+func (c *CSV) localizeError(err error) error {
+	var rowErr *RowError
+	if errors.As(err, &rowErr) && rowErr == err {
+		localized := *rowErr
+		localized.Err = c.localizeError(rowErr.Err)
+		return &localized
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		return err
+	}
+	if e.localizer != nil && (e.secondary != nil || c.secondaryLocalizer == nil) {
+		return err
+	}
+
+	localized := *e
+	if localized.localizer == nil {
+		localized.localizer = c.i18nLocalizer
+	}
+	if localized.secondary == nil {
+		localized.secondary = c.secondaryLocalizer
+	}
+	if e == err {
+		return &localized
+	}
+	return &localizedError{err: err, original: e, localized: &localized}
+}
+
+// localizeErrors calls localizeError for each error. The errors are replaced in the slice.
+func (c *CSV) localizeErrors(errs []error) []error {
+	for i, err := range errs {
+		errs[i] = c.localizeError(err)
+	}
+	return errs
+}
+
+// localizedError is an error that wraps an error, and replaces the message of the Error in it
+// with the localized one, e.g. fmt.Errorf("check: %w", NewError(nil, id, "")) in the custom rule.
+type localizedError struct {
+	err       error
+	original  *Error
+	localized *Error
+}
+
+// Error returns the message of the wrapped error with the localized message of the Error.
+func (l *localizedError) Error() string {
+	return strings.Replace(l.err.Error(), l.original.Error(), l.localized.Error(), 1)
+}
+
+// Unwrap returns the wrapped error.
+func (l *localizedError) Unwrap() error {
+	return l.err
+}
+
+// As sets the localized Error to the target if the target is **Error.
+func (l *localizedError) As(target any) bool {
+	if t, ok := target.(**Error); ok {
+		*t = l.localized
+		return true
+	}
+	return false
+}
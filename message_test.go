@@ -0,0 +1,137 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRegisterErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom validator returns the localized error", func(t *testing.T) {
+		t.Parallel()
+
+		err := RegisterErrorMessage("ErrTestEvenNumber", map[string]string{
+			"en": "target is not an even number",
+			"ja": "値が偶数ではありません",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		even := func(_ RowContext, value string) error {
+			if value != "" && (value[len(value)-1]-'0')%2 != 0 {
+				return NewError(nil, "ErrTestEvenNumber", "value="+value)
+			}
+			return nil
+		}
+
+		tests := []struct {
+			name string
+			opts []Option
+			want string
+		}{
+			{
+				name: "english",
+				opts: []Option{WithValidator("even", even)},
+				want: "line:3 column id: target is not an even number: value=3",
+			},
+			{
+				name: "japanese",
+				opts: []Option{WithValidator("even", even), WithJapaneseLanguage()},
+				want: "line:3 column id: 値が偶数ではありません: value=3",
+			},
+			{
+				name: "russian falls back to english",
+				opts: []Option{WithValidator("even", even), WithRussianLanguage()},
+				want: "line:3 column id: target is not an even number: value=3",
+			},
+		}
+
+		for _, tt := range tests {
+			c, err := NewCSV(bytes.NewBufferString("id\n2\n3\n"), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			type record struct {
+				ID int `validate:"even"`
+			}
+			records := make([]record, 0)
+
+			errs := c.Decode(&records)
+			if len(errs) != 1 {
+				t.Fatalf("%s: CSV.Decode() got errors: %v", tt.name, errs)
+			}
+			if errs[0].Error() != tt.want {
+				t.Errorf("%s: CSV.Decode() got error %q, want %q", tt.name, errs[0].Error(), tt.want)
+			}
+		}
+	})
+
+	t.Run("should not modify the sentinel error returned by the custom validator", func(t *testing.T) {
+		t.Parallel()
+
+		err := RegisterErrorMessage("ErrTestSentinel", map[string]string{
+			"en": "target is rejected",
+			"ja": "値が拒否されました",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		errRejected := NewError(nil, "ErrTestSentinel", "")
+		reject := func(_ RowContext, _ string) error {
+			return fmt.Errorf("rejected: %w", errRejected)
+		}
+
+		type record struct {
+			ID int `validate:"reject"`
+		}
+		for _, tt := range []struct {
+			opts []Option
+			want string
+		}{
+			{opts: []Option{WithValidator("reject", reject), WithJapaneseLanguage()}, want: "line:2 column id: rejected: 値が拒否されました"},
+			{opts: []Option{WithValidator("reject", reject)}, want: "line:2 column id: rejected: target is rejected"},
+		} {
+			c, err := NewCSV(bytes.NewBufferString("id\n1\n"), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			records := make([]record, 0)
+			errs := c.Decode(&records)
+			if len(errs) != 1 || errs[0].Error() != tt.want {
+				t.Errorf("CSV.Decode() got errors %v, want %q", errs, tt.want)
+			}
+			if !errors.Is(errs[0], errRejected) {
+				t.Errorf("CSV.Decode() got error %v, want to wrap the sentinel error", errs[0])
+			}
+		}
+		if got := errRejected.Error(); got != "ErrTestSentinel" {
+			t.Errorf("sentinel error was modified: %q", got)
+		}
+	})
+
+	t.Run("should return an error if the message is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name         string
+			id           string
+			translations map[string]string
+		}{
+			{name: "empty id", id: "", translations: map[string]string{"en": "message"}},
+			{name: "no english", id: "ErrTestNoEnglish", translations: map[string]string{"ja": "メッセージ"}},
+			{name: "invalid language", id: "ErrTestInvalidLanguage", translations: map[string]string{"en": "message", "!!": "message"}},
+		}
+		for _, tt := range tests {
+			if err := RegisterErrorMessage(tt.id, tt.translations); err == nil {
+				t.Errorf("%s: RegisterErrorMessage() got nil error", tt.name)
+			}
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// Metrics is the data quality metrics of a call of Decode, DecodeContext, DecodeChunk,
+// DecodeMapBy, AnnotateTo or ValidateSample. It is reported by the hook set by WithMetrics.
+type Metrics struct {
+	// Rows is the number of records validated in the call. The header is not counted.
+	Rows int
+	// Errors is the number of errors returned by the call.
+	Errors int
+	// ErrorsByRule is the number of validation errors of each rule. The key is the error ID
+	// (e.g. ErrRequiredID) of the built-in rule or the name of the custom rule registered by WithValidator.
+	ErrorsByRule map[string]int
+	// Duration is the time taken by the call.
+	Duration time.Duration
+	// SkippedRules is the sorted names of the rules disabled by WithSkipRules.
+	SkippedRules []string
+}
+
+// metricsCollector collects the metrics of a call. The methods do nothing if it is nil,
+// i.e. WithMetrics is not set.
+type metricsCollector struct {
+	start        time.Time
+	rows         int
+	errorsByRule map[string]int
+}
+
+// startMetrics starts collecting the metrics of a call if WithMetrics is set.
+func (c *CSV) startMetrics() {
+	if c.metricsHook == nil {
+		return
+	}
+	c.metrics = &metricsCollector{start: time.Now(), errorsByRule: make(map[string]int)}
+}
+
+// reportMetrics calls the hook set by WithMetrics with the collected metrics.
+func (c *CSV) reportMetrics(ctx context.Context, errs []error) {
+	if c.metrics == nil {
+		return
+	}
+	c.metricsHook(ctx, Metrics{
+		Rows:         c.metrics.rows,
+		Errors:       len(errs),
+		ErrorsByRule: c.metrics.errorsByRule,
+		Duration:     time.Since(c.metrics.start),
+		SkippedRules: c.skippedRules(),
+	})
+	c.metrics = nil
+}
+
+// skippedRules returns the sorted names of the rules disabled by WithSkipRules.
+func (c *CSV) skippedRules() []string {
+	names := make([]string, 0, len(c.skipRules))
+	for name := range c.skipRules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addRow counts a validated record.
+func (m *metricsCollector) addRow() {
+	if m == nil {
+		return
+	}
+	m.rows++
+}
+
+// addError counts the validation error of the validator.
+func (m *metricsCollector) addError(v validator, err error) {
+	if m == nil {
+		return
+	}
+	if cv, ok := v.(*customValidator); ok {
+		m.errorsByRule[cv.name]++
+		return
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		m.errorsByRule[e.id]++
+	}
+}
@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("report rows and errors by rule", func(t *testing.T) {
+		t.Parallel()
+
+		input := `id,name,age
+1,Gina,23
+a,,25
+b,Den1s,30
+`
+		var got []Metrics
+		hook := func(_ context.Context, m Metrics) {
+			got = append(got, m)
+		}
+		adult := func(_ RowContext, value string) error {
+			if value < "25" {
+				return errors.New("not adult")
+			}
+			return nil
+		}
+
+		c, err := NewCSV(bytes.NewBufferString(input), WithMetrics(hook), WithValidator("adult", adult))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID   int    `validate:"numeric"`
+			Name string `validate:"required,alpha"`
+			Age  int    `validate:"adult"`
+		}
+		people := make([]person, 0)
+
+		errs := c.Decode(&people)
+		if len(got) != 1 {
+			t.Fatalf("WithMetrics() hook called %d times, want 1", len(got))
+		}
+		if got[0].Rows != 3 || got[0].Errors != len(errs) || got[0].Duration <= 0 {
+			t.Errorf("WithMetrics() got %+v, errors=%d", got[0], len(errs))
+		}
+		want := map[string]int{
+			ErrInvalidNumericID:  2,
+			ErrRequiredID:        1,
+			ErrInvalidAlphabetID: 1,
+			"adult":              1,
+		}
+		if diff := cmp.Diff(got[0].ErrorsByRule, want); diff != "" {
+			t.Errorf("WithMetrics() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("report each chunk", func(t *testing.T) {
+		t.Parallel()
+
+		var rows []int
+		hook := func(_ context.Context, m Metrics) {
+			rows = append(rows, m.Rows)
+		}
+
+		c, err := NewCSV(bytes.NewBufferString("id\n1\n2\n3\n"), WithMetrics(hook))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type record struct {
+			ID int `validate:"numeric"`
+		}
+		records := make([]record, 0)
+		for done := false; !done; {
+			done, _ = c.DecodeChunk(&records, 2)
+		}
+		if diff := cmp.Diff(rows, []int{2, 1}); diff != "" {
+			t.Errorf("WithMetrics() mismatch (-got +want):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package csv
+
+import (
+	"strings"
+)
+
+// normalizers is a set of normalizer for a column.
+// The normalizers are applied in the order of the normalize tag.
+type normalizers []normalizer
+
+// normalizer is a function that converts the value before validation and assignment.
+type normalizer func(value string) string
+
+// apply applies all normalizers to the value.
+func (n normalizers) apply(value string) string {
+	for _, f := range n {
+		value = f(value)
+	}
+	return value
+}
+
+// parseNormalizeTag parses the normalize tag.
+// tags is the value of the struct tag. e.g. trim,lower
+func (c *CSV) parseNormalizeTag(tags string) (normalizers, error) {
+	if tags == "" {
+		return normalizers{}, nil
+	}
+
+	tagList := strings.Split(tags, ",")
+	normalizerList := make(normalizers, 0, len(tagList))
+	for _, t := range tagList {
+		switch tagValue(t) {
+		case trimTagValue:
+			normalizerList = append(normalizerList, strings.TrimSpace)
+		case lowerTagValue:
+			normalizerList = append(normalizerList, strings.ToLower)
+		case upperTagValue:
+			normalizerList = append(normalizerList, strings.ToUpper)
+		default:
+			return nil, NewError(c.i18nLocalizer, ErrInvalidNormalizeTagID, t)
+		}
+	}
+	return normalizerList, nil
+}
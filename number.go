@@ -0,0 +1,56 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+)
+
+// numberFormat is the format of numbers in the CSV.
+type numberFormat struct {
+	// decimalSep is the decimal separator. e.g. ',' for "1.234,56"
+	decimalSep rune
+	// thousandsSep is the thousands separator. e.g. '.' for "1.234,56"
+	// If it is 0, the value has no thousands separator.
+	thousandsSep rune
+}
+
+// normalize converts the value into the format that strconv can parse.
+// e.g. "1.234,56" is converted into "1234.56" if decimalSep is ',' and thousandsSep is '.'.
+func (n *numberFormat) normalize(value string) string {
+	if n == nil {
+		return value
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case n.thousandsSep:
+			return -1
+		case n.decimalSep:
+			return '.'
+		}
+		return r
+	}, value)
+}
+
+// isNumberValidator returns true if the validator parses the target as a number.
+func isNumberValidator(v validator) bool {
+	switch v.(type) {
+	case *numericValidator, *equalValidator, *notEqualValidator,
+		*greaterThanValidator, *greaterThanEqualValidator,
+		*lessThanValidator, *lessThanEqualValidator,
+		*minValidator, *maxValidator, *columnStatValidator:
+		return true
+	}
+	return false
+}
+
+// isNumberKind returns true if the kind is an integer or a floating point number.
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -1,6 +1,11 @@
 package csv
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
@@ -15,6 +20,35 @@ func WithTabDelimiter() Option {
 	}
 }
 
+// WithSourceDelimiter is an Option that sets the delimiter of the input, e.g. WithSourceDelimiter(';').
+// It overrides the tab delimiter that NewCSVFS and ValidateFiles set for the .tsv and .tab files.
+func WithSourceDelimiter(r rune) Option {
+	return func(c *CSV) error {
+		if !isValidDelimiter(r) {
+			return NewError(c.i18nLocalizer, ErrInvalidDelimiterID, fmt.Sprintf("delimiter=%q", r))
+		}
+		c.reader.Comma = r
+		return nil
+	}
+}
+
+// WithOutputDelimiter is an Option that sets the delimiter of the CSV written by AnnotateTo,
+// e.g. WithOutputDelimiter('\t') writes TSV. The default is the delimiter of the input.
+func WithOutputDelimiter(r rune) Option {
+	return func(c *CSV) error {
+		if !isValidDelimiter(r) {
+			return NewError(c.i18nLocalizer, ErrInvalidDelimiterID, fmt.Sprintf("delimiter=%q", r))
+		}
+		c.outputDelimiter = r
+		return nil
+	}
+}
+
+// isValidDelimiter returns true if encoding/csv accepts the rune as the delimiter.
+func isValidDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
 // WithHeaderless is an Option that sets the headerless flag to true.
 func WithHeaderless() Option {
 	return func(c *CSV) error {
@@ -23,6 +57,31 @@ func WithHeaderless() Option {
 	}
 }
 
+// WithSkipRows is an Option that skips the first n lines before the header, e.g. metadata
+// banners exported by spreadsheets or reporting tools. The skipped lines do not have to be
+// valid CSV records. The line numbers in errors count the skipped lines, so they match the
+// line numbers of the original file. The skipped lines are not written by AnnotateTo.
+func WithSkipRows(n int) Option {
+	return func(c *CSV) error {
+		if n < 0 {
+			return NewError(c.i18nLocalizer, ErrInvalidSkipRowsID, fmt.Sprintf("n=%d", n))
+		}
+		c.skipRows = n
+		return nil
+	}
+}
+
+// WithAutoDetect is an Option that detects the delimiter (comma, tab or semicolon) and whether
+// the CSV has a header from the first 8KB of the input. It is useful for user-uploaded files of
+// unknown provenance. The first record is treated as a header if it has no empty, numeric or
+// duplicate values. The detected format overrides WithTabDelimiter and WithHeaderless.
+func WithAutoDetect() Option {
+	return func(c *CSV) error {
+		c.autoDetectFormat = true
+		return nil
+	}
+}
+
 // WithJapaneseLanguage is an Option that sets the i18n bundle to Japanese.
 func WithJapaneseLanguage() Option {
 	return func(c *CSV) error {
@@ -38,3 +97,269 @@ func WithRussianLanguage() Option {
 		return nil
 	}
 }
+
+// WithBilingualErrors is an Option that localizes the error messages of Decode in two languages,
+// e.g. WithBilingualErrors("en", "ja") returns "target is required but is empty / 必須パラメータが空です: value=".
+// It is useful for the reports read by both the engineers and the business users.
+// The supported languages are "en", "ja" and "ru".
+func WithBilingualErrors(primary, secondary string) Option {
+	return func(c *CSV) error {
+		for _, lang := range []string{primary, secondary} {
+			if !c.isSupportedLanguage(lang) {
+				return NewError(c.i18nLocalizer, ErrUnsupportedLanguageID, fmt.Sprintf("language=%s", lang))
+			}
+		}
+		c.i18nLocalizer = i18n.NewLocalizer(c.i18nBundle, primary)
+		c.secondaryLocalizer = i18n.NewLocalizer(c.i18nBundle, secondary)
+		return nil
+	}
+}
+
+// WithLookup is an Option that validates the values of the column are included in the values.
+// The name is the header name of the CSV column, or the column number (the first column is "1")
+// if the CSV has no header. It is useful when the allowed values are too many to be written in the struct tag.
+// Decode returns an error if the struct has no field for the column.
+func WithLookup(name string, values []string) Option {
+	return func(c *CSV) error {
+		if c.lookups == nil {
+			c.lookups = make(map[column]*lookupValidator)
+		}
+		c.lookups[column(name)] = newLookupValidator(values)
+		return nil
+	}
+}
+
+// WithValidator is an Option that registers the custom validation rule with the tag name.
+// The rule is used in the same way as the built-in rules, e.g. `validate:"required,even"`.
+// If the name is the same as a built-in rule, the custom rule takes precedence.
+func WithValidator(name string, fn ValidatorFunc) Option {
+	return func(c *CSV) error {
+		if name == "" || strings.ContainsAny(name, ",= ") || fn == nil {
+			return NewError(c.i18nLocalizer, ErrInvalidValidatorID, name)
+		}
+		if c.customValidators == nil {
+			c.customValidators = make(map[string]ValidatorFunc)
+		}
+		c.customValidators[name] = fn
+		return nil
+	}
+}
+
+// WithNumberFormat is an Option that sets the decimal separator and the thousands separator.
+// It is used by the numeric and comparison rules (e.g. gt, lte, min) and when decoding into
+// integer or float fields. For example, WithNumberFormat(',', '.') parses "1.234,56" as 1234.56.
+// If thousandsSep is 0, the value is regarded as having no thousands separator.
+func WithNumberFormat(decimalSep, thousandsSep rune) Option {
+	return func(c *CSV) error {
+		if decimalSep == 0 || decimalSep == thousandsSep {
+			return NewError(c.i18nLocalizer, ErrInvalidNumberFormatID, fmt.Sprintf("decimal=%q, thousands=%q", decimalSep, thousandsSep))
+		}
+		c.numberFormat = &numberFormat{
+			decimalSep:   decimalSep,
+			thousandsSep: thousandsSep,
+		}
+		return nil
+	}
+}
+
+// WithChecksum is an Option that registers the checksum algorithm with the name.
+// The algorithm is used by the checksum tag, e.g. `validate:"checksum=isin"`.
+// If the name is the same as a built-in algorithm (luhn, mod97, damm), the registered one takes precedence.
+func WithChecksum(name string, fn ChecksumFunc) Option {
+	return func(c *CSV) error {
+		if name == "" || strings.ContainsAny(name, ",= ") || fn == nil {
+			return NewError(c.i18nLocalizer, ErrInvalidChecksumFormatID, name)
+		}
+		if c.checksums == nil {
+			c.checksums = make(map[string]ChecksumFunc)
+		}
+		c.checksums[name] = fn
+		return nil
+	}
+}
+
+// defaultDuplicateHeaderFormat is the default format of the renamed duplicate header columns.
+const defaultDuplicateHeaderFormat = "%s_%d"
+
+// WithStrictHeader is an Option that returns an error from Decode if the header has duplicate
+// columns (e.g. two "amount" columns). By default, the duplicate columns are renamed.
+// See WithDuplicateHeaderFormat.
+func WithStrictHeader() Option {
+	return func(c *CSV) error {
+		c.strictHeader = true
+		return nil
+	}
+}
+
+// WithDuplicateHeaderFormat is an Option that sets the format of the renamed duplicate header columns.
+// The format has one %s for the column name and one %d for the number of the occurrence, in this order.
+// The default is "%s_%d", so two "amount" columns are named amount and amount_2. The renamed header is
+// used in error messages, WithLookup and RowContext. The aliases of WithHeaderAliases are applied first.
+func WithDuplicateHeaderFormat(format string) Option {
+	return func(c *CSV) error {
+		s, d := strings.Index(format, "%s"), strings.Index(format, "%d")
+		if strings.Count(format, "%") != 2 || s < 0 || d < 0 || s > d {
+			return NewError(c.i18nLocalizer, ErrInvalidDuplicateHeaderFormatID, fmt.Sprintf("format=%s", format))
+		}
+		c.duplicateHeaderFormat = format
+		return nil
+	}
+}
+
+// WithHeaderAliases is an Option that renames the header columns of the CSV.
+// The key is the header name in the CSV and the value is the name used instead, e.g.
+// map[string]string{"顧客ID": "id", "氏名": "name"}. The renamed header is used in error
+// messages, WithLookup and RowContext, so localized or legacy headers can be handled
+// without editing the source files.
+func WithHeaderAliases(aliases map[string]string) Option {
+	return func(c *CSV) error {
+		if c.headerAliases == nil {
+			c.headerAliases = make(map[string]string, len(aliases))
+		}
+		for k, v := range aliases {
+			c.headerAliases[k] = v
+		}
+		return nil
+	}
+}
+
+// WithConcurrency is an Option that sets the number of files validated in parallel by ValidateFiles.
+// The default is 1. If n is greater than 1, the custom rules registered by WithValidator and
+// WithChecksum and the hooks set by WithBeforeRow and WithMetrics are called concurrently,
+// so they must be safe for concurrent use. It has no effect on Decode.
+func WithConcurrency(n int) Option {
+	return func(c *CSV) error {
+		if n <= 0 {
+			return NewError(c.i18nLocalizer, ErrInvalidConcurrencyID, fmt.Sprintf("n=%d", n))
+		}
+		c.concurrency = n
+		return nil
+	}
+}
+
+// WithSkipRules is an Option that disables the rules of the names in the validate tags,
+// e.g. WithSkipRules("email", "in_file") while onboarding a legacy file, without editing the struct tags.
+// The custom rules registered by WithValidator can also be disabled. The disabled rules are
+// reported as Metrics.SkippedRules by the hook set by WithMetrics for auditing. They are not
+// included in the errors returned by Decode, which have only the problems of the CSV, so set
+// WithMetrics to record the audit note of the run.
+func WithSkipRules(names ...string) Option {
+	return func(c *CSV) error {
+		if c.skipRules == nil {
+			c.skipRules = make(map[string]struct{}, len(names))
+		}
+		for _, name := range names {
+			if name == "" || strings.ContainsAny(name, ",=") {
+				return NewError(c.i18nLocalizer, ErrInvalidSkipRuleID, fmt.Sprintf("name=%s", name))
+			}
+			c.skipRules[name] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithMetrics is an Option that sets the hook called with the metrics at the end of each call of
+// Decode, DecodeContext, DecodeChunk, DecodeMapBy, AnnotateTo and ValidateSample. The metrics
+// have the number of rows, the number of errors by rule and the duration, so an ingestion service
+// can report them to its monitoring system (e.g. OpenTelemetry) and watch the data quality trends.
+// The ctx is the context passed to DecodeContext, or context.Background() for the other methods.
+// ValidateFiles calls the hook once per file, so the hook must be safe for concurrent use
+// if WithConcurrency is greater than 1.
+func WithMetrics(hook func(ctx context.Context, m Metrics)) Option {
+	return func(c *CSV) error {
+		c.metricsHook = hook
+		return nil
+	}
+}
+
+// WithBeforeRow is an Option that sets the hook called before each record is validated.
+// The hook receives the line number and the record, and returns the record to be validated
+// and decoded, so it can patch legacy quirks (e.g. strip BOM artifacts, fix known bad codes).
+// If the hook returns an error, the error is returned as RowError and the record is skipped.
+func WithBeforeRow(hook func(line int, record []string) ([]string, error)) Option {
+	return func(c *CSV) error {
+		c.beforeRowHook = hook
+		return nil
+	}
+}
+
+// WithAfterRow is an Option that sets the hook called after each record is decoded.
+// The hook receives the line number and the pointer to the decoded struct, so it can enrich
+// the struct inline. If the hook returns an error, the error is returned as RowError.
+// The struct is still appended to the slice. ValidateSample and ValidateFiles do not call the hook
+// because they do not keep the structs.
+func WithAfterRow(hook func(line int, v any) error) Option {
+	return func(c *CSV) error {
+		c.afterRowHook = hook
+		return nil
+	}
+}
+
+// WithNAValues is an Option that treats the specified strings as a missing value,
+// e.g. WithNAValues("NA", "N/A", "-", "null"). A cell that exactly matches one of the
+// values is decoded as an empty string, so the struct field becomes the zero value
+// and the validators see an empty value (the required tag reports it).
+// The values are compared after the normalize tag is applied.
+func WithNAValues(values ...string) Option {
+	return func(c *CSV) error {
+		if c.naValues == nil {
+			c.naValues = make(map[string]struct{}, len(values))
+		}
+		for _, v := range values {
+			c.naValues[v] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithFirstErrorPerCell is an Option that stops validating a value at the first failed rule.
+// The rules are checked in the order they are written in the validate tag, so a non-numeric age
+// with `validate:"numeric,gt=0,lt=150"` reports only the numeric error.
+func WithFirstErrorPerCell() Option {
+	return func(c *CSV) error {
+		c.firstErrorPerCell = true
+		return nil
+	}
+}
+
+// WithZeroCopyScan is an Option for validation-only passes over huge files. Decode validates the records
+// without appending the structs to the slice, and the record slice of the reader (csv.Reader.ReuseRecord)
+// and the buffers of each record are reused, so the memory does not grow with the number of records.
+// The record passed to the WithBeforeRow hook, RowContext.Record and the struct passed to
+// the WithAfterRow hook must not be retained. DecodeMapBy returns an error with this option.
+func WithZeroCopyScan() Option {
+	return func(c *CSV) error {
+		c.zeroCopyScan = true
+		c.reader.ReuseRecord = true
+		return nil
+	}
+}
+
+// WithPerRowMaxErrors is an Option that returns at most k detailed errors per line.
+// The rest of the errors on the line are summarized into one RowError without column
+// (e.g. "line:4: more violations on this line are omitted: n=3"), so a badly malformed
+// line does not flood the error list.
+func WithPerRowMaxErrors(k int) Option {
+	return func(c *CSV) error {
+		if k <= 0 {
+			return NewError(c.i18nLocalizer, ErrInvalidPerRowMaxErrorsID, fmt.Sprintf("k=%d", k))
+		}
+		c.perRowMaxErrors = k
+		return nil
+	}
+}
+
+// WithValidationCache is an Option that caches the validation results of at most size
+// distinct (rule, value) pairs in an LRU cache. Columns like country or status repeat the
+// same few values, so the expensive rules (e.g. email, url_path, in_file) run once per distinct value.
+// The rules that refer to the other fields (e.g. excluded_if), the rules that depend on the current
+// time (within_days) and custom rules are not cached.
+func WithValidationCache(size int) Option {
+	return func(c *CSV) error {
+		if size <= 0 {
+			return NewError(c.i18nLocalizer, ErrInvalidCacheSizeID, fmt.Sprintf("size=%d", size))
+		}
+		c.validationCache = newValidationCache(size)
+		return nil
+	}
+}
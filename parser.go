@@ -1,11 +1,18 @@
 package csv
 
 import (
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // parseStructTag parses the struct tag and extracts the header and ruleSet.
@@ -23,11 +30,24 @@ func (c *CSV) parseStructTag(structSlicePointer any) error {
 		if elemType.Kind() != reflect.Struct {
 			return NewError(c.i18nLocalizer, ErrStructSlicePointerID, "")
 		}
+		if c.structType == elemType {
+			return nil // the rules keep the state (e.g. col_mean_lte) between DecodeChunk calls.
+		}
 		ruleSet, err := c.extractRuleSet(elemType)
 		if err != nil {
 			return err
 		}
 		c.ruleSet = ruleSet
+		c.ruleKeys = extractRuleKeys(elemType, ruleSet)
+
+		normalizerSet, err := c.extractNormalizerSet(elemType)
+		if err != nil {
+			return err
+		}
+		c.normalizerSet = normalizerSet
+		c.defaults = extractDefaults(elemType)
+		c.structType = elemType
+		c.lookupsApplied = false
 	default:
 		return NewError(c.i18nLocalizer, ErrStructSlicePointerID, fmt.Sprintf("element=%v", elem.Kind()))
 	}
@@ -44,121 +64,523 @@ func (c *CSV) extractRuleSet(structType reflect.Type) (ruleSet, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := c.bindFields(structType, validators); err != nil {
+			return nil, err
+		}
 		ruleSet = append(ruleSet, validators)
 	}
 	return ruleSet, nil
 }
 
+// extractRuleKeys returns the keys of the validation cache for the validators of the ruleSet.
+// The key is the validate tag and the index of the validator in it, so the same rule in
+// the different fields or structs shares the cached results.
+func extractRuleKeys(structType reflect.Type, ruleSet ruleSet) [][]string {
+	ruleKeys := make([][]string, 0, len(ruleSet))
+	for i, validators := range ruleSet {
+		tag := structType.Field(i).Tag.Get(validateTag.String())
+		keys := make([]string, 0, len(validators))
+		for j := range validators {
+			keys = append(keys, tag+"\x00"+strconv.Itoa(j))
+		}
+		ruleKeys = append(ruleKeys, keys)
+	}
+	return ruleKeys
+}
+
+// bindFields resolves the struct field names referred by the cross field validators
+// into the column indexes.
+func (c *CSV) bindFields(structType reflect.Type, validators validators) error {
+	for _, v := range validators {
+		cv, ok := v.(crossFieldValidator)
+		if !ok {
+			continue
+		}
+
+		indexes := make([]int, 0, len(cv.fields()))
+		for _, name := range cv.fields() {
+			field, ok := structType.FieldByName(name)
+			if !ok || len(field.Index) != 1 {
+				subMessage := withSuggestion(c.i18nLocalizer, fmt.Sprintf("field=%s", name), name, fieldNames(structType))
+				return NewError(c.i18nLocalizer, ErrFieldNotFoundID, subMessage)
+			}
+			indexes = append(indexes, field.Index[0])
+		}
+		cv.bind(indexes)
+	}
+	return nil
+}
+
+// fieldNames returns the names of the fields of the struct.
+func fieldNames(structType reflect.Type) []string {
+	names := make([]string, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		names = append(names, structType.Field(i).Name)
+	}
+	return names
+}
+
+// extractNormalizerSet extracts the normalizers of each field from the struct.
+func (c *CSV) extractNormalizerSet(structType reflect.Type) ([]normalizers, error) {
+	normalizerSet := make([]normalizers, 0, structType.NumField())
+
+	for i := 0; i < structType.NumField(); i++ {
+		tag := structType.Field(i).Tag
+		normalizers, err := c.parseNormalizeTag(tag.Get(normalizeTag.String()))
+		if err != nil {
+			return nil, err
+		}
+		normalizerSet = append(normalizerSet, normalizers)
+	}
+	return normalizerSet, nil
+}
+
+// extractDefaults extracts the values of the default tags from the struct. The key is the field index.
+// The fields with the required rule have no default value, so that the required rule reports the empty cell.
+func extractDefaults(structType reflect.Type) map[int]string {
+	defaults := make(map[int]string)
+	for i := 0; i < structType.NumField(); i++ {
+		tag := structType.Field(i).Tag
+		value, ok := tag.Lookup(defaultTag.String())
+		if !ok || hasRequiredRule(tag.Get(validateTag.String())) {
+			continue
+		}
+		defaults[i] = value
+	}
+	return defaults
+}
+
+// hasRequiredRule returns true if the validate tag has the required rule.
+func hasRequiredRule(tags string) bool {
+	for _, t := range strings.Split(tags, ",") {
+		if t == requiredTagValue.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // parseValidateTag parses the validate tag.
 // This function return a set of Validate functions based on
 // the rules specified in the validation tag.
 func (c *CSV) parseValidateTag(tags string) (validators, error) {
 	tagList := strings.Split(tags, ",")
 	validatorList := make(validators, 0, len(tagList))
+	itemSeparator := c.parseItemSeparator(tagList)
 
 	for _, t := range tagList {
-		switch {
-		case strings.HasPrefix(t, booleanTagValue.String()):
-			validatorList = append(validatorList, newBooleanValidator())
-		case strings.HasPrefix(t, alphaTagValue.String()) && !strings.HasPrefix(t, alphanumericTagValue.String()):
-			validatorList = append(validatorList, newAlphaValidator())
-		case strings.HasPrefix(t, numericTagValue.String()):
-			validatorList = append(validatorList, newNumericValidator())
-		case strings.HasPrefix(t, alphanumericTagValue.String()):
-			validatorList = append(validatorList, newAlphanumericValidator())
-		case strings.HasPrefix(t, requiredTagValue.String()):
-			validatorList = append(validatorList, newRequiredValidator())
-		case strings.HasPrefix(t, equalTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newEqualValidator(threshold))
-		case strings.HasPrefix(t, notEqualTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newNotEqualValidator(threshold))
-		case strings.HasPrefix(t, greaterThanTagValue.String()) && !strings.HasPrefix(t, greaterThanEqualTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newGreaterThanValidator(threshold))
-		case strings.HasPrefix(t, greaterThanEqualTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newGreaterThanEqualValidator(threshold))
-		case strings.HasPrefix(t, lessThanTagValue.String()) && !strings.HasPrefix(t, lessThanEqualTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newLessThanValidator(threshold))
-		case strings.HasPrefix(t, lessThanEqualTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newLessThanEqualValidator(threshold))
-		case strings.HasPrefix(t, minTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newMinValidator(threshold))
-		case strings.HasPrefix(t, maxTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newMaxValidator(threshold))
-		case strings.HasPrefix(t, lengthTagValue.String()):
-			threshold, err := c.parseThreshold(t)
-			if err != nil {
-				return nil, err
-			}
-			validatorList = append(validatorList, newLengthValidator(threshold))
-		case strings.HasPrefix(t, oneOfTagValue.String()):
-			oneOf, err := c.parseSpecifiedValues(t)
-			if err != nil {
-				return nil, NewError(c.i18nLocalizer, ErrInvalidOneOfFormatID, t)
-			}
-			validatorList = append(validatorList, newOneOfValidator(oneOf))
-		case strings.HasPrefix(t, lowercaseTagValue.String()):
-			validatorList = append(validatorList, newLowercaseValidator())
-		case strings.HasPrefix(t, uppercaseTagValue.String()):
-			validatorList = append(validatorList, newUppercaseValidator())
-		case strings.HasPrefix(t, asciiTagValue.String()):
-			validatorList = append(validatorList, newASCIIValidator())
-		case strings.HasPrefix(t, emailTagValue.String()):
-			validatorList = append(validatorList, newEmailValidator())
-		case strings.HasPrefix(t, containsTagValue.String()) && !strings.HasPrefix(t, containsAnyTagValue.String()):
-			values, err := c.parseSpecifiedValues(t)
-			if err != nil {
-				return nil, err
-			}
-			if len(values) != 1 {
-				return nil, NewError(c.i18nLocalizer, ErrInvalidContainsFormatID, t)
-			}
-			validatorList = append(validatorList, newContainsValidator(values[0]))
-		case strings.HasPrefix(t, containsAnyTagValue.String()):
-			values, err := c.parseSpecifiedValues(t)
-			if err != nil {
-				return nil, err
-			}
-			if len(values) == 0 {
-				return nil, NewError(c.i18nLocalizer, ErrInvalidContainsAnyFormatID, t)
-			}
-			validatorList = append(validatorList, newContainsAnyValidator(values))
+		v, err := c.parseValidateTagValue(t, tagList, itemSeparator)
+		if err != nil {
+			return nil, err
 		}
+		validatorList = append(validatorList, v...)
 	}
 	return validatorList, nil
 }
 
+// parseValidateTagValue parses a value of the validate tag. The tagList is the whole validate tag
+// because some rules refer to the other values, e.g. before and within_days use the timezone.
+func (c *CSV) parseValidateTagValue(t string, tagList []string, itemSeparator string) (validators, error) {
+	validatorList := make(validators, 0, 1)
+	if _, ok := c.skipRules[strings.SplitN(t, "=", 2)[0]]; ok {
+		return validatorList, nil
+	}
+	if fn, ok := c.customValidators[t]; ok {
+		validatorList = append(validatorList, newCustomValidator(t, fn))
+		return validatorList, nil
+	}
+
+	switch {
+	case strings.HasPrefix(t, booleanTagValue.String()):
+		validatorList = append(validatorList, newBooleanValidator())
+	case strings.HasPrefix(t, alphaTagValue.String()) &&
+		!strings.HasPrefix(t, alphanumericTagValue.String()) &&
+		!strings.HasPrefix(t, alphaUnicodeSpaceTagValue.String()):
+		validatorList = append(validatorList, newAlphaValidator())
+	case strings.HasPrefix(t, numericTagValue.String()) &&
+		!strings.HasPrefix(t, numericUnicodeTagValue.String()) &&
+		!strings.HasPrefix(t, numericLenTagValue.String()):
+		validatorList = append(validatorList, newNumericValidator())
+	case strings.HasPrefix(t, alphanumericTagValue.String()):
+		validatorList = append(validatorList, newAlphanumericValidator())
+	case strings.HasPrefix(t, requiredTagValue.String()):
+		validatorList = append(validatorList, newRequiredValidator())
+	case strings.HasPrefix(t, equalTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newEqualValidator(threshold))
+	case strings.HasPrefix(t, notEqualTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newNotEqualValidator(threshold))
+	case strings.HasPrefix(t, greaterThanFieldTagValue.String()),
+		strings.HasPrefix(t, greaterThanEqualFieldTagValue.String()),
+		strings.HasPrefix(t, lessThanFieldTagValue.String()),
+		strings.HasPrefix(t, lessThanEqualFieldTagValue.String()):
+		v, err := c.parseFieldComparison(t, tagList)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, v)
+	case strings.HasPrefix(t, greaterThanTagValue.String()) && !strings.HasPrefix(t, greaterThanEqualTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newGreaterThanValidator(threshold))
+	case strings.HasPrefix(t, greaterThanEqualTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newGreaterThanEqualValidator(threshold))
+	case strings.HasPrefix(t, lessThanTagValue.String()) && !strings.HasPrefix(t, lessThanEqualTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newLessThanValidator(threshold))
+	case strings.HasPrefix(t, lessThanEqualTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newLessThanEqualValidator(threshold))
+	case strings.HasPrefix(t, minLengthTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newMinLengthValidator(threshold))
+	case strings.HasPrefix(t, maxLengthTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newMaxLengthValidator(threshold))
+	case strings.HasPrefix(t, minTagValue.String()) && !strings.HasPrefix(t, minItemsTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newMinValidator(threshold))
+	case strings.HasPrefix(t, maxTagValue.String()) && !strings.HasPrefix(t, maxItemsTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newMaxValidator(threshold))
+	case strings.HasPrefix(t, lengthTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newLengthValidator(threshold))
+	case strings.HasPrefix(t, oneOfNumTagValue.String()):
+		oneOf, err := c.parseSpecifiedValues(t)
+		if err != nil {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidOneOfFormatID, t)
+		}
+		validatorList = append(validatorList, newOneOfNumValidator(oneOf))
+	case strings.HasPrefix(t, oneOfCITagValue.String()):
+		oneOf, err := c.parseSpecifiedValues(t)
+		if err != nil {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidOneOfFormatID, t)
+		}
+		validatorList = append(validatorList, newOneOfCIValidator(oneOf))
+	case strings.HasPrefix(t, oneOfTagValue.String()):
+		oneOf, err := c.parseSpecifiedValues(t)
+		if err != nil {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidOneOfFormatID, t)
+		}
+		validatorList = append(validatorList, newOneOfValidator(oneOf))
+	case strings.HasPrefix(t, lowercaseTagValue.String()):
+		validatorList = append(validatorList, newLowercaseValidator())
+	case strings.HasPrefix(t, uppercaseTagValue.String()):
+		validatorList = append(validatorList, newUppercaseValidator())
+	case strings.HasPrefix(t, asciiTagValue.String()):
+		validatorList = append(validatorList, newASCIIValidator())
+	case strings.HasPrefix(t, emailTagValue.String()):
+		validatorList = append(validatorList, newEmailValidator())
+	case strings.HasPrefix(t, containsTagValue.String()) && !strings.HasPrefix(t, containsAnyTagValue.String()):
+		values, err := c.parseSpecifiedValues(t)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidContainsFormatID, t)
+		}
+		validatorList = append(validatorList, newContainsValidator(values[0]))
+	case strings.HasPrefix(t, containsAnyTagValue.String()):
+		values, err := c.parseSpecifiedValues(t)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidContainsAnyFormatID, t)
+		}
+		validatorList = append(validatorList, newContainsAnyValidator(values))
+	case strings.HasPrefix(t, htmlEncodedTagValue.String()):
+		validatorList = append(validatorList, newHTMLEncodedValidator())
+	case strings.HasPrefix(t, xmlTagValue.String()):
+		validatorList = append(validatorList, newXMLValidator())
+	case strings.HasPrefix(t, urlPathTagValue.String()):
+		validatorList = append(validatorList, newURLPathValidator())
+	case strings.HasPrefix(t, urlQueryTagValue.String()):
+		validatorList = append(validatorList, newURLQueryValidator())
+	case strings.HasPrefix(t, domainTagValue.String()):
+		validatorList = append(validatorList, newDomainValidator())
+	case strings.HasPrefix(t, inFileTagValue.String()):
+		values, err := c.parseInFile(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newLookupValidator(values))
+	case strings.HasPrefix(t, numericUnicodeTagValue.String()):
+		validatorList = append(validatorList, newNumericUnicodeValidator())
+	case strings.HasPrefix(t, noWhitespaceTagValue.String()):
+		validatorList = append(validatorList, newNoWhitespaceValidator())
+	case strings.HasPrefix(t, singleLineTagValue.String()):
+		validatorList = append(validatorList, newSingleLineValidator())
+	case strings.HasPrefix(t, excludedIfTagValue.String()):
+		values, err := c.parseSpecifiedValues(t)
+		if err != nil || len(values)%2 != 0 {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidExcludedIfFormatID, t)
+		}
+		validatorList = append(validatorList, newExcludedIfValidator(values))
+	case strings.HasPrefix(t, excludedWithTagValue.String()):
+		values, err := c.parseSpecifiedValues(t)
+		if err != nil || len(values) == 0 || values[0] == "" {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidExcludedWithFormatID, t)
+		}
+		validatorList = append(validatorList, newExcludedWithValidator(values))
+	case strings.HasPrefix(t, luhnTagValue.String()),
+		strings.HasPrefix(t, mod97TagValue.String()),
+		strings.HasPrefix(t, dammTagValue.String()):
+		fn, ok := c.checksum(t)
+		if !ok {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidChecksumFormatID, t)
+		}
+		validatorList = append(validatorList, newChecksumValidator(t, fn))
+	case strings.HasPrefix(t, checksumTagValue.String()):
+		values, err := c.parseSpecifiedValues(t)
+		if err != nil || len(values) != 1 {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidChecksumFormatID, t)
+		}
+		fn, ok := c.checksum(values[0])
+		if !ok {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidChecksumFormatID, t)
+		}
+		validatorList = append(validatorList, newChecksumValidator(values[0], fn))
+	case strings.HasPrefix(t, itemSeparatorTagValue.String()):
+		// item_sep is already parsed by parseItemSeparator.
+	case strings.HasPrefix(t, uniqueItemsTagValue.String()):
+		validatorList = append(validatorList, newUniqueItemsValidator(itemSeparator))
+	case strings.HasPrefix(t, minItemsTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newMinItemsValidator(itemSeparator, threshold))
+	case strings.HasPrefix(t, maxItemsTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newMaxItemsValidator(itemSeparator, threshold))
+	case strings.HasPrefix(t, alphaUnicodeSpaceTagValue.String()):
+		validatorList = append(validatorList, newAlphaUnicodeSpaceValidator())
+	case strings.HasPrefix(t, personNameTagValue.String()):
+		validatorList = append(validatorList, newPersonNameValidator())
+	case strings.HasPrefix(t, idFormatTagValue.String()):
+		parts := strings.SplitN(t, "=", 2)
+		if len(parts) != 2 {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidIDFormatID, t)
+		}
+		v, err := newIDFormatValidator(parts[1])
+		if err != nil {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidIDFormatID, t)
+		}
+		validatorList = append(validatorList, v)
+	case strings.HasPrefix(t, colMeanGTETagValue.String()),
+		strings.HasPrefix(t, colMeanLTETagValue.String()),
+		strings.HasPrefix(t, colStddevGTETagValue.String()),
+		strings.HasPrefix(t, colStddevLTETagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, newColumnStatValidator(tagValue(strings.SplitN(t, "=", 2)[0]), threshold))
+	case strings.HasPrefix(t, digitsTagValue.String()):
+		threshold, err := c.parseThreshold(t)
+		if err != nil || threshold < 1 || threshold != math.Trunc(threshold) {
+			return nil, NewError(c.i18nLocalizer, ErrInvalidDigitsFormatID, t)
+		}
+		validatorList = append(validatorList, newDigitsValidator(int(threshold), int(threshold)))
+	case strings.HasPrefix(t, numericLenTagValue.String()):
+		v, err := c.parseNumericLen(t)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, v)
+	case strings.HasPrefix(t, zenkakuTagValue.String()):
+		validatorList = append(validatorList, newZenkakuValidator())
+	case strings.HasPrefix(t, hankakuTagValue.String()):
+		validatorList = append(validatorList, newHankakuValidator())
+	case strings.HasPrefix(t, katakanaTagValue.String()):
+		validatorList = append(validatorList, newKatakanaValidator())
+	case strings.HasPrefix(t, hiraganaTagValue.String()):
+		validatorList = append(validatorList, newHiraganaValidator())
+	case t == md5TagValue.String(), t == sha1TagValue.String(), t == sha256TagValue.String(),
+		t == sha384TagValue.String(), t == sha512TagValue.String():
+		validatorList = append(validatorList, newHashValidator(tagValue(t)))
+	case t == ipPrivateTagValue.String(), t == ipPublicTagValue.String(), t == ipLoopbackTagValue.String():
+		validatorList = append(validatorList, newIPClassValidator(tagValue(t)))
+	case strings.HasPrefix(t, timezoneTagValue.String()):
+		v, err := c.parseTimezone(t, tagList)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, v)
+	case strings.HasPrefix(t, beforeTagValue.String()), strings.HasPrefix(t, afterTagValue.String()):
+		v, err := c.parseTimeBound(t, tagList)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, v)
+	case strings.HasPrefix(t, withinDaysTagValue.String()):
+		v, err := c.parseWithinDays(t, tagList)
+		if err != nil {
+			return nil, err
+		}
+		validatorList = append(validatorList, v)
+	case strings.HasPrefix(t, dateTimeTagValue.String()):
+		validatorList = append(validatorList, newTimeValidator(parseTimeLayout(dateTimeTagValue, t)))
+	case strings.HasPrefix(t, dateTagValue.String()):
+		validatorList = append(validatorList, newTimeValidator(parseTimeLayout(dateTagValue, t)))
+	case strings.HasPrefix(t, timeTagValue.String()):
+		validatorList = append(validatorList, newTimeValidator(parseTimeLayout(timeTagValue, t)))
+	}
+	return validatorList, nil
+}
+
+// parseItemSeparator returns the separator specified by item_sep tag.
+// The default separator is "," because "," can not be written in the tag.
+func (c *CSV) parseItemSeparator(tagList []string) string {
+	const defaultItemSeparator = ","
+
+	prefix := itemSeparatorTagValue.String() + "="
+	for _, t := range tagList {
+		if strings.HasPrefix(t, prefix) && len(t) > len(prefix) {
+			return strings.TrimPrefix(t, prefix)
+		}
+	}
+	return defaultItemSeparator
+}
+
+// parseTimezone parses the timezone tag. The layout is taken from the date, datetime or time tag of the same field.
+// tagValue is the value of the struct tag. e.g. timezone=UTC, timezone=Asia/Tokyo
+func (c *CSV) parseTimezone(tagValue string, tagList []string) (*timezoneValidator, error) {
+	parts := strings.SplitN(tagValue, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimezoneFormatID, tagValue)
+	}
+
+	layout, ok := findTimeLayout(tagList)
+	if !ok {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimezoneFormatID, tagValue)
+	}
+
+	location, err := time.LoadLocation(parts[1])
+	if err != nil {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimezoneFormatID, fmt.Sprintf("%s: %v", tagValue, err))
+	}
+	return newTimezoneValidator(layout, location), nil
+}
+
+// parseTimeBound parses the before and after tags. The layout is taken from the date, datetime or time tag of the same field.
+// tagValue is the value of the struct tag. e.g. before=2030-01-01, after=2000-01-01
+func (c *CSV) parseTimeBound(tagValue string, tagList []string) (*timeBoundValidator, error) {
+	parts := strings.SplitN(tagValue, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimeRangeFormatID, tagValue)
+	}
+
+	layout, ok := findTimeLayout(tagList)
+	if !ok {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimeRangeFormatID, tagValue)
+	}
+
+	bound, err := parseBoundTime(layout, parts[1])
+	if err != nil {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimeRangeFormatID, tagValue)
+	}
+	if parts[0] == afterTagValue.String() {
+		return newTimeBoundValidator(afterTagValue, layout, bound, tagValue), nil
+	}
+	return newTimeBoundValidator(beforeTagValue, layout, bound, tagValue), nil
+}
+
+// parseFieldComparison parses the gtfield, gtefield, ltfield and ltefield tags. If the field has the date,
+// datetime or time tag, the values are compared as the time parsed with its layout.
+// tagValue is the value of the struct tag. e.g. gtfield=StartDate
+func (c *CSV) parseFieldComparison(tagValue string, tagList []string) (*fieldComparisonValidator, error) {
+	parts := strings.SplitN(tagValue, "=", 2)
+	if len(parts) != 2 || parts[1] == "" || strings.Contains(parts[1], " ") {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidFieldComparisonFormatID, tagValue)
+	}
+
+	layout, _ := findTimeLayout(tagList)
+	return newFieldComparisonValidator(tagValue, parts[0], parts[1], layout), nil
+}
+
+// parseWithinDays parses the within_days tag. The layout is taken from the date, datetime or time tag of the same field.
+// tagValue is the value of the struct tag. e.g. within_days=30
+func (c *CSV) parseWithinDays(tagValue string, tagList []string) (*withinDaysValidator, error) {
+	parts := strings.SplitN(tagValue, "=", 2)
+	if len(parts) != 2 {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimeRangeFormatID, tagValue)
+	}
+
+	days, err := strconv.Atoi(parts[1])
+	if err != nil || days < 0 {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimeRangeFormatID, tagValue)
+	}
+
+	layout, ok := findTimeLayout(tagList)
+	if !ok {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidTimeRangeFormatID, tagValue)
+	}
+	return newWithinDaysValidator(layout, days), nil
+}
+
+// parseNumericLen parses the numeric_len tag.
+// tagValue is the value of the struct tag. e.g. numeric_len=3:8
+func (c *CSV) parseNumericLen(tagValue string) (*digitsValidator, error) {
+	parts := strings.SplitN(tagValue, "=", 2)
+	if len(parts) != 2 {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidDigitsFormatID, tagValue)
+	}
+
+	bounds := strings.Split(parts[1], ":")
+	if len(bounds) != 2 {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidDigitsFormatID, tagValue)
+	}
+	min, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidDigitsFormatID, tagValue)
+	}
+	max, err := strconv.Atoi(bounds[1])
+	if err != nil || min < 1 || min > max {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidDigitsFormatID, tagValue)
+	}
+	return newDigitsValidator(min, max), nil
+}
+
 // parseThreshold parses the threshold value.
 // tagValue is the value of the struct tag. e.g. eq=10, gt=5.2
 func (c *CSV) parseThreshold(tagValue string) (float64, error) {
@@ -176,11 +598,136 @@ func (c *CSV) parseThreshold(tagValue string) (float64, error) {
 
 // parseSpecifiedValues parses the tag values.
 // tagValue is the value of the struct tag. e.g. oneof=male female prefer_not_to
+// A value that contains spaces can be quoted with single quotes. e.g. oneof='not available' ok
 func (c *CSV) parseSpecifiedValues(tagValue string) ([]string, error) {
 	parts := strings.Split(tagValue, "=")
 
 	if len(parts) == 2 {
-		return strings.Split(parts[1], " "), nil
+		return splitSpecifiedValues(parts[1])
 	}
 	return nil, errors.New("invalid tag values format")
 }
+
+// splitSpecifiedValues splits the values by space. A value that starts with a single quote is quoted
+// until the quote followed by a space or the end, so the spaces in it are not separators and the quotes
+// are removed. The quotes in the middle of a value are kept, e.g. O'Brien.
+func splitSpecifiedValues(s string) ([]string, error) {
+	values := make([]string, 0)
+	runes := []rune(s)
+	var (
+		value  strings.Builder
+		quoted bool
+		start  = true
+	)
+	for i, r := range runes {
+		switch {
+		case r == '\'' && start:
+			quoted = true
+		case r == '\'' && quoted && (i == len(runes)-1 || runes[i+1] == ' '):
+			quoted = false
+		case r == ' ' && !quoted:
+			values = append(values, value.String())
+			value.Reset()
+			start = true
+			continue
+		default:
+			value.WriteRune(r)
+		}
+		start = false
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote in tag values")
+	}
+	return append(values, value.String()), nil
+}
+
+// joinSpecifiedValues joins the values by space for the error messages. It is the reverse of
+// splitSpecifiedValues, so the values that contain spaces, start with a quote or are empty are quoted.
+// e.g. 'New York' Tokyo
+func joinSpecifiedValues(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || strings.Contains(v, " ") || strings.HasPrefix(v, "'") {
+			v = "'" + v + "'"
+		}
+		quoted = append(quoted, v)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// parseInFile parses the in_file tag value and loads the lookup values from the file.
+// tagValue is the value of the struct tag. e.g. in_file=testdata/allowed_codes.csv:code
+func (c *CSV) parseInFile(tagValue string) ([]string, error) {
+	parts := strings.SplitN(tagValue, "=", 2)
+	if len(parts) != 2 {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidInFileFormatID, tagValue)
+	}
+
+	sep := strings.LastIndex(parts[1], ":")
+	if sep <= 0 || sep == len(parts[1])-1 {
+		return nil, NewError(c.i18nLocalizer, ErrInvalidInFileFormatID, tagValue)
+	}
+	if values, ok := c.lookupFiles[parts[1]]; ok {
+		return values, nil
+	}
+
+	values, err := c.loadLookupFile(parts[1][:sep], parts[1][sep+1:])
+	if err != nil {
+		return nil, err
+	}
+	if c.lookupFiles == nil {
+		c.lookupFiles = make(map[string][]string)
+	}
+	c.lookupFiles[parts[1]] = values
+	return values, nil
+}
+
+// loadLookupFile reads the values of the specified column from the CSV file.
+// The first line of the file must be a header.
+func (c *CSV) loadLookupFile(path, columnName string) ([]string, error) {
+	f, err := c.openFile(path)
+	if err != nil {
+		return nil, NewError(c.i18nLocalizer, ErrLoadLookupFileID, err.Error())
+	}
+	defer f.Close() //nolint:errcheck // read only.
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, NewError(c.i18nLocalizer, ErrLoadLookupFileID, fmt.Sprintf("path=%s: %v", path, err))
+	}
+
+	index := -1
+	for i, v := range header {
+		if v == columnName {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, NewError(c.i18nLocalizer, ErrLoadLookupFileID, fmt.Sprintf("path=%s: column %s is not found", path, columnName))
+	}
+
+	values := make([]string, 0)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, NewError(c.i18nLocalizer, ErrLoadLookupFileID, fmt.Sprintf("path=%s: %v", path, err))
+		}
+		if index < len(record) {
+			values = append(values, record[index])
+		}
+	}
+	return values, nil
+}
+
+// openFile opens the file from the file system set by NewCSVFS, or the OS file system.
+func (c *CSV) openFile(path string) (fs.File, error) {
+	if c.fsys != nil {
+		return c.fsys.Open(path)
+	}
+	return os.Open(filepath.Clean(path))
+}
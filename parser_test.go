@@ -30,6 +30,33 @@ func Test_parseValidateTag(t *testing.T) {
 				newAlphaValidator(),
 			},
 		},
+		{
+			name: "should distinguish alpha from alphaunicodespace",
+			args: args{tags: "alphaunicodespace,person_name"},
+			want: validators{
+				newAlphaUnicodeSpaceValidator(),
+				newPersonNameValidator(),
+			},
+		},
+		{
+			name: "should distinguish min and max from minlen and maxlen",
+			args: args{tags: "minlen=1,maxlen=5,min=0,max=9"},
+			want: validators{
+				newMinLengthValidator(1),
+				newMaxLengthValidator(5),
+				newMinValidator(0),
+				newMaxValidator(9),
+			},
+		},
+		{
+			name: "should distinguish numeric from numeric_len",
+			args: args{tags: "numeric_len=3:8,numeric,digits=6"},
+			want: validators{
+				newDigitsValidator(3, 8),
+				newNumericValidator(),
+				newDigitsValidator(6, 6),
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -43,7 +70,8 @@ func Test_parseValidateTag(t *testing.T) {
 				t.Errorf("parseValidateTag() error = %v, test case at %s", err, dataloc.L(tt.name))
 			}
 
-			if diff := cmp.Diff(got, tt.want); diff != "" {
+			opt := cmp.AllowUnexported(minLengthValidator{}, maxLengthValidator{}, minValidator{}, maxValidator{}, digitsValidator{})
+			if diff := cmp.Diff(got, tt.want, opt); diff != "" {
 				t.Errorf("parseValidateTage() mismatch (-got +want):\n%s", diff)
 			}
 		})
@@ -87,3 +115,76 @@ func TestCSV_parseStructTag(t *testing.T) {
 		})
 	}
 }
+
+func Test_splitSpecifiedValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "split by space", arg: "male female", want: []string{"male", "female"}},
+		{name: "keep spaces in quotes", arg: "'not available' ok", want: []string{"not available", "ok"}},
+		{name: "keep empty value in quotes", arg: "'' ok", want: []string{"", "ok"}},
+		{name: "keep quote in the middle of value", arg: "O'Brien Smith", want: []string{"O'Brien", "Smith"}},
+		{name: "keep quote in the middle of quoted value", arg: "'O'Brien Smith' ok", want: []string{"O'Brien Smith", "ok"}},
+		{name: "unterminated quote", arg: "'not available ok", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := splitSpecifiedValues(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitSpecifiedValues() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+			if diff := cmp.Diff(got, tt.want); !tt.wantErr && diff != "" {
+				t.Errorf("splitSpecifiedValues() mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_joinSpecifiedValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		arg  []string
+		want string
+	}{
+		{name: "join by space", arg: []string{"male", "female"}, want: "male female"},
+		{name: "quote value with spaces", arg: []string{"New York", "Tokyo"}, want: "'New York' Tokyo"},
+		{name: "quote empty value", arg: []string{"", "ok"}, want: "'' ok"},
+		{name: "quote value starting with quote", arg: []string{"'ok", "O'Brien"}, want: "''ok' O'Brien"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := joinSpecifiedValues(tt.arg); got != tt.want {
+				t.Errorf("joinSpecifiedValues() = %q, want %q, test case at %s", got, tt.want, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func TestCSV_parseNumericLen(t *testing.T) {
+	t.Parallel()
+
+	for _, tag := range []string{"numeric_len=3", "numeric_len=a:8", "numeric_len=8:3", "numeric_len=0:3", "digits=0", "digits=1.5"} {
+		tag := tag
+		t.Run(tag, func(t *testing.T) {
+			t.Parallel()
+			c, err := NewCSV(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.parseValidateTag(tag); err == nil {
+				t.Errorf("parseValidateTag(%q) got nil error", tag)
+			}
+		})
+	}
+}
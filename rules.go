@@ -0,0 +1,97 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Rule is a validation rule set in the struct tag.
+type Rule struct {
+	// Name is the rule name. e.g. "gt", "oneof"
+	Name string
+	// Params is the parameters of the rule. e.g. ["5"] for "gt=5", ["male", "female"] for "oneof=male female"
+	Params []string
+}
+
+// ColumnRules is the rules of a column.
+type ColumnRules struct {
+	// Index is the column index. The first column is 0.
+	Index int
+	// Field is the struct field name.
+	Field string
+	// Column is the header name. It is empty if the header has not been read yet or the CSV has no header.
+	Column string
+	// Normalize is the normalize rules. e.g. ["trim", "lower"]
+	Normalize []string
+	// Default is the value of the default tag used when the cell is empty. It is nil if the field has no default tag
+	// or has the required rule, because the default tag is ignored for the required fields.
+	Default *string
+	// Rules is the validation rules. Rules that the csv package does not recognize are not included.
+	Rules []Rule
+}
+
+// Rules returns the effective rules of each column. The structSlicePointer is a pointer to
+// structure slice where rules are set in struct tags, the same as Decode. It does not read the CSV,
+// so tools can render data-contract documentation from the tagged structs.
+func (c *CSV) Rules(structSlicePointer any) ([]ColumnRules, error) {
+	if err := c.parseStructTag(structSlicePointer); err != nil {
+		return nil, err
+	}
+
+	structType := reflect.TypeOf(structSlicePointer).Elem().Elem()
+	columnRules := make([]ColumnRules, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		cr := ColumnRules{
+			Index:     i,
+			Field:     field.Name,
+			Normalize: splitTag(field.Tag.Get(normalizeTag.String())),
+			Rules:     make([]Rule, 0),
+		}
+		if i < len(c.header) {
+			cr.Column = string(c.header[i])
+		}
+		if v, ok := c.defaults[i]; ok {
+			cr.Default = &v
+		}
+
+		tagList := splitTag(field.Tag.Get(validateTag.String()))
+		itemSeparator := c.parseItemSeparator(tagList)
+		for _, t := range tagList {
+			validators, err := c.parseValidateTagValue(t, tagList, itemSeparator)
+			if err != nil {
+				return nil, err
+			}
+			if len(validators) == 0 && !strings.HasPrefix(t, itemSeparatorTagValue.String()) {
+				continue
+			}
+			cr.Rules = append(cr.Rules, newRule(t))
+		}
+		columnRules = append(columnRules, cr)
+	}
+	return columnRules, nil
+}
+
+// newRule returns a new Rule from the tag value. e.g. "oneof=male female"
+// The params are split in the same way as the validators, so the quoted value is one param.
+// e.g. "oneof='New York' Tokyo" has ["New York", "Tokyo"]
+func newRule(tagValue string) Rule {
+	parts := strings.SplitN(tagValue, "=", 2)
+	rule := Rule{Name: parts[0], Params: []string{}}
+	if len(parts) == 2 {
+		params, err := splitSpecifiedValues(parts[1])
+		if err != nil {
+			params = []string{parts[1]}
+		}
+		rule.Params = params
+	}
+	return rule
+}
+
+// splitTag splits the struct tag value by ",". It returns an empty slice if the tag is empty.
+func splitTag(tags string) []string {
+	if tags == "" {
+		return []string{}
+	}
+	return strings.Split(tags, ",")
+}
@@ -0,0 +1,130 @@
+package csv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCSV_Rules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return rules of each column", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString(""))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type person struct {
+			ID     int    `validate:"numeric,gte=1"`
+			Name   string `normalize:"trim" validate:"alpha,unknown_rule"`
+			Gender string `validate:"oneof=male female"`
+			City   string `validate:"oneof='New York' Tokyo"`
+			Tags   string `validate:"item_sep=|,unique_items"`
+			Note   string `default:"none"`
+			Code   string `default:"XX" validate:"required"`
+		}
+
+		got, err := c.Rules(&[]person{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []ColumnRules{
+			{
+				Index:     0,
+				Field:     "ID",
+				Normalize: []string{},
+				Rules:     []Rule{{Name: "numeric", Params: []string{}}, {Name: "gte", Params: []string{"1"}}},
+			},
+			{
+				Index:     1,
+				Field:     "Name",
+				Normalize: []string{"trim"},
+				Rules:     []Rule{{Name: "alpha", Params: []string{}}},
+			},
+			{
+				Index:     2,
+				Field:     "Gender",
+				Normalize: []string{},
+				Rules:     []Rule{{Name: "oneof", Params: []string{"male", "female"}}},
+			},
+			{
+				Index:     3,
+				Field:     "City",
+				Normalize: []string{},
+				Rules:     []Rule{{Name: "oneof", Params: []string{"New York", "Tokyo"}}},
+			},
+			{
+				Index:     4,
+				Field:     "Tags",
+				Normalize: []string{},
+				Rules:     []Rule{{Name: "item_sep", Params: []string{"|"}}, {Name: "unique_items", Params: []string{}}},
+			},
+			{
+				Index:     5,
+				Field:     "Note",
+				Normalize: []string{},
+				Rules:     []Rule{},
+			},
+			{
+				Index:     6,
+				Field:     "Code",
+				Normalize: []string{},
+				Rules:     []Rule{{Name: "required", Params: []string{}}},
+			},
+		}
+		want[5].Default = new(string)
+		*want[5].Default = "none"
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("CSV.Rules() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("should return the rules that refer to the other rules of the field", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString(""))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type event struct {
+			StartAt   string `validate:"datetime,timezone=UTC"`
+			EndAt     string `validate:"date,before=2030-01-01"`
+			UpdatedAt string `validate:"datetime,within_days=30"`
+		}
+
+		got, err := c.Rules(&[]event{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := [][]Rule{
+			{{Name: "datetime", Params: []string{}}, {Name: "timezone", Params: []string{"UTC"}}},
+			{{Name: "date", Params: []string{}}, {Name: "before", Params: []string{"2030-01-01"}}},
+			{{Name: "datetime", Params: []string{}}, {Name: "within_days", Params: []string{"30"}}},
+		}
+		for i, cr := range got {
+			if diff := cmp.Diff(cr.Rules, want[i]); diff != "" {
+				t.Errorf("CSV.Rules() mismatch of %s (-got +want):\n%s", cr.Field, diff)
+			}
+		}
+	})
+
+	t.Run("should return an error if the argument is not a pointer to struct slice", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString(""))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := c.Rules([]int{}); err == nil {
+			t.Error("CSV.Rules() got nil error")
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// sniffSize is the number of bytes read from the head of the CSV to detect the format.
+const sniffSize = 8 * 1024
+
+// sniffDelimiters is the candidates of the delimiter in the order of priority.
+var sniffDelimiters = []rune{',', '\t', ';'}
+
+// autoDetect detects the delimiter and whether the CSV has a header from the head of r,
+// and replaces the reader of the CSV. The settings of the old reader are kept except Comma.
+func (c *CSV) autoDetect(r io.Reader) error {
+	buffered := bufio.NewReaderSize(r, sniffSize)
+	sample, err := buffered.Peek(sniffSize)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return err
+	}
+
+	c.resetReader(buffered)
+	delimiter, records := sniffDelimiter(sample, len(sample) < sniffSize)
+	c.reader.Comma = delimiter
+	c.headerless = len(records) > 0 && !looksLikeHeader(records[0])
+	return nil
+}
+
+// resetReader replaces the CSV reader with a new reader of r. The settings of the old reader are kept.
+func (c *CSV) resetReader(r io.Reader) {
+	old := c.reader
+	c.reader = csv.NewReader(r)
+	c.reader.Comma = old.Comma
+	c.reader.Comment = old.Comment
+	c.reader.FieldsPerRecord = old.FieldsPerRecord
+	c.reader.LazyQuotes = old.LazyQuotes
+	c.reader.TrimLeadingSpace = old.TrimLeadingSpace
+	c.reader.ReuseRecord = old.ReuseRecord
+}
+
+// skipLines skips the leading lines set by WithSkipRows, e.g. metadata banners before the header.
+// The lines are skipped as raw text, so they do not have to be valid CSV records.
+// It returns the reader of the rest of the input.
+func (c *CSV) skipLines(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	for i := 0; i < c.skipRows; i++ {
+		if _, err := buffered.ReadString('\n'); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+	}
+	c.resetReader(buffered)
+	return buffered, nil
+}
+
+// read reads a record from the CSV reader. The line numbers of *csv.ParseError count from
+// the reader after the lines skipped by WithSkipRows, so the skipped lines are added to them
+// to point to the lines of the original file.
+func (c *CSV) read() ([]string, error) {
+	record, err := c.reader.Read()
+	var parseErr *csv.ParseError
+	if c.skipRows > 0 && errors.As(err, &parseErr) {
+		parseErr.StartLine += c.skipRows
+		parseErr.Line += c.skipRows
+	}
+	return record, err
+}
+
+// sniffDelimiter returns the delimiter that splits all records of the sample into the same number
+// of fields, and the most fields. If complete is false, the last line of the sample is ignored because
+// it may be cut off. If no candidate fits, it returns ',' as the default delimiter.
+func sniffDelimiter(sample []byte, complete bool) (rune, [][]string) {
+	if !complete {
+		if i := bytes.LastIndexByte(sample, '\n'); i >= 0 {
+			sample = sample[:i+1]
+		}
+	}
+
+	var (
+		best        = sniffDelimiters[0]
+		bestRecords [][]string
+		bestFields  = 1
+	)
+	for _, d := range sniffDelimiters {
+		reader := csv.NewReader(bytes.NewReader(sample))
+		reader.Comma = d
+		records, err := reader.ReadAll()
+		if err != nil || len(records) == 0 {
+			continue
+		}
+		if fields := len(records[0]); fields > bestFields {
+			best, bestRecords, bestFields = d, records, fields
+		}
+	}
+	if bestRecords == nil {
+		records, _ := csv.NewReader(bytes.NewReader(sample)).ReadAll() //nolint:errcheck // only used to guess the header.
+		return best, records
+	}
+	return best, bestRecords
+}
+
+// looksLikeHeader guesses whether the record is a header. A header has no empty, numeric
+// or duplicate values, e.g. "id,name,age" is a header but "1,Alice,20" is not.
+func looksLikeHeader(record []string) bool {
+	seen := make(map[string]struct{}, len(record))
+	for _, v := range record {
+		if v == "" {
+			return false
+		}
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return false
+		}
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+	}
+	return true
+}
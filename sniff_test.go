@@ -0,0 +1,128 @@
+package csv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/motemen/go-testutil/dataloc"
+)
+
+func Test_sniffDelimiter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		sample   string
+		complete bool
+		want     rune
+	}{
+		{name: "comma", sample: "id,name\n1,Alice\n", complete: true, want: ','},
+		{name: "tab", sample: "id\tname\n1\tAlice\n", complete: true, want: '\t'},
+		{name: "semicolon with decimal comma", sample: "id;price\n1;1,5\n2;3,25\n", complete: true, want: ';'},
+		{name: "comma in quotes", sample: "name;note\n\"a,b\";c\n", complete: true, want: ';'},
+		{name: "single column", sample: "id\n1\n", complete: true, want: ','},
+		{name: "last line is cut off", sample: "id,name\n1,Alice\n2,Bo", complete: false, want: ','},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got, _ := sniffDelimiter([]byte(tt.sample), tt.complete); got != tt.want {
+				t.Errorf("sniffDelimiter() = %q, want %q, test case at %s", got, tt.want, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_looksLikeHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		record []string
+		want   bool
+	}{
+		{name: "names", record: []string{"id", "name", "age"}, want: true},
+		{name: "numeric value", record: []string{"1", "Alice", "20"}, want: false},
+		{name: "empty value", record: []string{"id", ""}, want: false},
+		{name: "duplicate values", record: []string{"Tokyo", "Tokyo"}, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := looksLikeHeader(tt.record); got != tt.want {
+				t.Errorf("looksLikeHeader() = %v, want %v, test case at %s", got, tt.want, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func TestWithAutoDetect(t *testing.T) {
+	t.Parallel()
+
+	type person struct {
+		ID   int    `validate:"numeric"`
+		Name string `validate:"alpha"`
+	}
+
+	t.Run("detect semicolon and header", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("id;name\n1;Alice\n2;Bob1\n"), WithAutoDetect())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		people := make([]person, 0)
+		errs := c.Decode(&people)
+		if len(errs) != 1 || errs[0].Error() != "line:3 column name: target is not an alphabetic character: value=Bob1" {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+		if diff := cmp.Diff(people, []person{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob1"}}); diff != "" {
+			t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("detect tab and no header", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("1\tAlice\n2\tBob\n"), WithAutoDetect())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		people := make([]person, 0)
+		if errs := c.Decode(&people); len(errs) != 0 {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+		if diff := cmp.Diff(people, []person{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}); diff != "" {
+			t.Errorf("CSV.Decode() mismatch (-got +want):\n%s", diff)
+		}
+	})
+
+	t.Run("read the input longer than the sample", func(t *testing.T) {
+		t.Parallel()
+
+		var b strings.Builder
+		b.WriteString("id\tname\n")
+		for b.Len() < sniffSize*2 {
+			b.WriteString("1\tAlice\n")
+		}
+
+		c, err := NewCSV(strings.NewReader(b.String()), WithAutoDetect())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		people := make([]person, 0)
+		if errs := c.Decode(&people); len(errs) != 0 {
+			t.Errorf("CSV.Decode() got errors: %v", errs)
+		}
+		if want := strings.Count(b.String(), "\n") - 1; len(people) != want {
+			t.Errorf("CSV.Decode() got %d records, want %d", len(people), want)
+		}
+	})
+}
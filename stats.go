@@ -0,0 +1,106 @@
+package csv
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// columnStatValidator is a struct that contains the validation rules for the statistics of a column.
+// Unlike the other validators, it does not validate a value. It accumulates the values of the column
+// and the statistics are checked by check after all records are read.
+type columnStatValidator struct {
+	// tag is the struct tag name. e.g. col_mean_lte
+	tag tagValue
+	// threshold is the threshold of the statistic.
+	threshold float64
+	// count is the number of the numeric values.
+	count int
+	// mean is the running mean of the values (Welford's algorithm).
+	mean float64
+	// m2 is the running sum of squares of differences from the mean.
+	m2 float64
+}
+
+// newColumnStatValidator returns a new columnStatValidator.
+func newColumnStatValidator(tag tagValue, threshold float64) *columnStatValidator {
+	return &columnStatValidator{tag: tag, threshold: threshold}
+}
+
+// Do accumulates the target. Empty or non-numeric values are ignored because
+// the other rules (e.g. required, numeric) report them.
+func (s *columnStatValidator) Do(_ *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil
+	}
+	s.count++
+	delta := value - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (value - s.mean)
+	return nil
+}
+
+// stddev returns the sample standard deviation of the values.
+func (s *columnStatValidator) stddev() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return math.Sqrt(s.m2 / float64(s.count-1))
+}
+
+// check validates the statistic of the accumulated values. If the column has no numeric value,
+// it returns nil.
+func (s *columnStatValidator) check(localizer *i18n.Localizer) error {
+	if s.count == 0 {
+		return nil
+	}
+
+	switch s.tag {
+	case colMeanGTETagValue:
+		if s.mean < s.threshold {
+			return NewError(localizer, ErrColumnMeanID, fmt.Sprintf("%s=%v, mean=%v", s.tag, s.threshold, s.mean))
+		}
+	case colMeanLTETagValue:
+		if s.mean > s.threshold {
+			return NewError(localizer, ErrColumnMeanID, fmt.Sprintf("%s=%v, mean=%v", s.tag, s.threshold, s.mean))
+		}
+	case colStddevGTETagValue:
+		if s.stddev() < s.threshold {
+			return NewError(localizer, ErrColumnStddevID, fmt.Sprintf("%s=%v, stddev=%v", s.tag, s.threshold, s.stddev()))
+		}
+	case colStddevLTETagValue:
+		if s.stddev() > s.threshold {
+			return NewError(localizer, ErrColumnStddevID, fmt.Sprintf("%s=%v, stddev=%v", s.tag, s.threshold, s.stddev()))
+		}
+	}
+	return nil
+}
+
+// checkColumnStats checks the statistics of the columns after all records are read.
+// The errors are returned as RowError with line 0 because they are not related to a line.
+// The accumulated values are reset, so the statistics are reported only once
+// even if DecodeChunk is called after the end of the CSV.
+func (c *CSV) checkColumnStats() []error {
+	errors := make([]error, 0)
+	for i, validators := range c.ruleSet {
+		for _, v := range validators {
+			s, ok := v.(*columnStatValidator)
+			if !ok {
+				continue
+			}
+			if err := s.check(c.i18nLocalizer); err != nil {
+				errors = append(errors, &RowError{Column: c.columnName(i), Err: err})
+			}
+			s.count, s.mean, s.m2 = 0, 0, 0
+		}
+	}
+	return errors
+}
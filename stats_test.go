@@ -0,0 +1,163 @@
+package csv
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/motemen/go-testutil/dataloc"
+)
+
+func Test_columnStatValidator_check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		tag       tagValue
+		threshold float64
+		values    []string
+		wantErr   bool
+	}{
+		{name: "mean is less than or equal to the threshold", tag: colMeanLTETagValue, threshold: 2, values: []string{"1", "2", "3"}, wantErr: false},
+		{name: "mean is greater than the threshold", tag: colMeanLTETagValue, threshold: 1.5, values: []string{"1", "2", "3"}, wantErr: true},
+		{name: "mean is greater than or equal to the threshold", tag: colMeanGTETagValue, threshold: 2, values: []string{"1", "2", "3"}, wantErr: false},
+		{name: "mean is less than the threshold", tag: colMeanGTETagValue, threshold: 2.5, values: []string{"1", "2", "3"}, wantErr: true},
+		{name: "stddev is less than or equal to the threshold", tag: colStddevLTETagValue, threshold: 1, values: []string{"1", "2", "3"}, wantErr: false},
+		{name: "stddev is greater than the threshold", tag: colStddevLTETagValue, threshold: 0.5, values: []string{"1", "2", "3"}, wantErr: true},
+		{name: "stddev is less than the threshold", tag: colStddevGTETagValue, threshold: 0.1, values: []string{"5", "5", "5"}, wantErr: true},
+		{name: "empty and non-numeric values are ignored", tag: colMeanLTETagValue, threshold: 2, values: []string{"", "abc", "2"}, wantErr: false},
+		{name: "no numeric value", tag: colMeanGTETagValue, threshold: 1, values: []string{"", "abc"}, wantErr: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := newColumnStatValidator(tt.tag, tt.threshold)
+			for _, v := range tt.values {
+				if err := s.Do(helperLocalizer(t), v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.check(helperLocalizer(t)); (err != nil) != tt.wantErr {
+				t.Errorf("columnStatValidator.check() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func TestCSV_DecodeColumnStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validate the statistics of the columns", func(t *testing.T) {
+		t.Parallel()
+
+		input := `endpoint,latency_ms
+/users,100
+/items,300
+/orders,500
+`
+		c, err := NewCSV(bytes.NewBufferString(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type metric struct {
+			Endpoint  string
+			LatencyMS int `validate:"lte=1000,col_mean_lte=250,col_stddev_lte=500"`
+		}
+		metrics := make([]metric, 0)
+
+		errs := c.Decode(&metrics)
+		want := []string{
+			"column latency_ms: mean of the column does not satisfy the threshold: col_mean_lte=250, mean=300",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("CSV.Decode() got %d errors, want %d: %v", len(errs), len(want), errs)
+		}
+		for i, err := range errs {
+			if err.Error() != want[i] {
+				t.Errorf("CSV.Decode() got error %q, want %q", err.Error(), want[i])
+			}
+		}
+
+		rowErrs, err := SplitErrors(errs)
+		if err != nil || len(rowErrs) != 1 || rowErrs[0].Line != 0 {
+			t.Errorf("SplitErrors() got %v, %v", rowErrs, err)
+		}
+	})
+
+	t.Run("report the statistics once with DecodeChunk", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("value\n10\n20\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type row struct {
+			Value int `validate:"col_mean_gte=100"`
+		}
+		rows := make([]row, 0)
+
+		done, errs := c.DecodeChunk(&rows, 10)
+		if !done || len(errs) != 1 {
+			t.Fatalf("CSV.DecodeChunk() got %v, %v", done, errs)
+		}
+		if done, errs = c.DecodeChunk(&rows, 10); !done || len(errs) != 0 {
+			t.Errorf("CSV.DecodeChunk() got %v, %v", done, errs)
+		}
+	})
+
+	t.Run("validate the statistics with ValidateSample and ValidateFiles", func(t *testing.T) {
+		t.Parallel()
+
+		type person struct {
+			ID   int
+			Name string
+			Age  int `validate:"col_mean_lte=20"`
+		}
+
+		c, err := NewCSV(bytes.NewBufferString("id,name,age\n1,Gina,23\n2,Yulia,0\n3,Denis,30\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs := c.ValidateSample(&[]person{}, 2)
+		want := "column age: mean of the column does not satisfy the threshold: col_mean_lte=20, mean=26.5"
+		if len(errs) != 1 || errs[0].Error() != want {
+			t.Errorf("CSV.ValidateSample() got errors %v, want %q", errs, want)
+		}
+
+		sample := filepath.Join("testdata", "sample.csv")
+		got, err := ValidateFiles[person]([]string{sample})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = "column age: mean of the column does not satisfy the threshold: col_mean_lte=20, mean=26"
+		if len(got[sample]) != 1 || got[sample][0].Error() != want {
+			t.Errorf("ValidateFiles() got errors %v, want %q", got[sample], want)
+		}
+	})
+
+	t.Run("validate the statistics of all chunks", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCSV(bytes.NewBufferString("value\n100\n200\n600\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type row struct {
+			Value int `validate:"col_mean_lte=250"`
+		}
+
+		var errs []error
+		for done := false; !done; {
+			rows := make([]row, 0)
+			done, errs = c.DecodeChunk(&rows, 1)
+		}
+		want := "column value: mean of the column does not satisfy the threshold: col_mean_lte=250, mean=300"
+		if len(errs) != 1 || errs[0].Error() != want {
+			t.Errorf("CSV.DecodeChunk() got errors %v, want %q", errs, want)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// didYouMeanID is the message ID of the suggestion appended to the error message.
+const didYouMeanID = "DidYouMean"
+
+// withSuggestion appends the localized suggestion of the closest candidate to the sub message.
+// e.g. "oneof=male female, value=femal (did you mean 'female'?)"
+// If no candidate is close enough, it returns the sub message as is.
+func withSuggestion(localizer *i18n.Localizer, subMessage, value string, candidates []string) string {
+	candidate, ok := closest(value, candidates)
+	if !ok {
+		return subMessage
+	}
+	suggestion := localize(localizer, didYouMeanID, map[string]string{"Value": candidate})
+	return fmt.Sprintf("%s (%s)", subMessage, suggestion)
+}
+
+// closest returns the candidate that has the smallest edit distance to the value.
+// A candidate is close enough if the distance is at most one third of its length (at least 1).
+// The comparison is case-insensitive, so "Male" suggests "male".
+func closest(value string, candidates []string) (string, bool) {
+	var (
+		best     string
+		bestDist = -1
+	)
+	for _, c := range candidates {
+		dist := levenshtein(strings.ToLower(value), strings.ToLower(c))
+		limit := len([]rune(c)) / 3
+		if limit < 1 {
+			limit = 1
+		}
+		if dist > limit {
+			continue
+		}
+		if bestDist == -1 || dist < bestDist {
+			best, bestDist = c, dist
+		}
+	}
+	return best, bestDist != -1
+}
+
+// levenshtein returns the Levenshtein distance between a and b in runes.
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
+
+// minInt returns the minimum of the values.
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,67 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/motemen/go-testutil/dataloc"
+)
+
+func Test_closest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		value      string
+		candidates []string
+		want       string
+		wantOK     bool
+	}{
+		{name: "one character is missing", value: "femal", candidates: []string{"male", "female"}, want: "female", wantOK: true},
+		{name: "different case", value: "Male", candidates: []string{"male", "female"}, want: "male", wantOK: true},
+		{name: "multi-byte characters", value: "とうきよう", candidates: []string{"とうきょう", "おおさか"}, want: "とうきょう", wantOK: true},
+		{name: "too far", value: "child", candidates: []string{"male", "female"}, wantOK: false},
+		{name: "no candidates", value: "male", candidates: nil, wantOK: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := closest(tt.value, tt.candidates)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("closest() = %q, %v, want %q, %v, test case at %s", got, ok, tt.want, tt.wantOK, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_levenshtein(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "abc", want: 3},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "female", b: "female", want: 0},
+		{a: "東京", b: "東都", want: 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func Test_withSuggestion(t *testing.T) {
+	t.Parallel()
+
+	got := withSuggestion(helperLocalizer(t), "value=femal", "femal", []string{"male", "female"})
+	if want := "value=femal (did you mean 'female'?)"; got != want {
+		t.Errorf("withSuggestion() = %q, want %q", got, want)
+	}
+	got = withSuggestion(helperLocalizer(t), "value=child", "child", []string{"male", "female"})
+	if want := "value=child"; got != want {
+		t.Errorf("withSuggestion() = %q, want %q", got, want)
+	}
+}
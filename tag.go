@@ -6,6 +6,10 @@ type tag string
 const (
 	// validateTag is the struct tag name for validation rules.
 	validateTag tag = "validate"
+	// normalizeTag is the struct tag name for normalization rules.
+	normalizeTag tag = "normalize"
+	// defaultTag is the struct tag name for the value used when the cell is empty.
+	defaultTag tag = "default"
 )
 
 // tagValue is the struct tag value.
@@ -40,8 +44,16 @@ const (
 	maxTagValue tagValue = "max"
 	// lengthTagValue is the struct tag name for length fields.
 	lengthTagValue tagValue = "len"
+	// minLengthTagValue is the struct tag name for minimum length fields.
+	minLengthTagValue tagValue = "minlen"
+	// maxLengthTagValue is the struct tag name for maximum length fields.
+	maxLengthTagValue tagValue = "maxlen"
 	// oneOfTagValue is the struct tag name for one of fields.
 	oneOfTagValue tagValue = "oneof"
+	// oneOfCITagValue is the struct tag name for one of fields compared case-insensitively.
+	oneOfCITagValue tagValue = "oneofci"
+	// oneOfNumTagValue is the struct tag name for one of fields compared numerically.
+	oneOfNumTagValue tagValue = "oneofnum"
 	// lowercaseTagValue is the struct tag name for lowercase fields.
 	lowercaseTagValue tagValue = "lowercase"
 	// uppercaseTagValue is the struct tag name for uppercase fields.
@@ -54,8 +66,200 @@ const (
 	containsTagValue tagValue = "contains"
 	// containsAnyTagValue is the struct tag name for contains any fields.
 	containsAnyTagValue tagValue = "containsany"
+	// htmlEncodedTagValue is the struct tag name for html encoded fields.
+	htmlEncodedTagValue tagValue = "html_encoded"
+	// xmlTagValue is the struct tag name for xml fields.
+	xmlTagValue tagValue = "xml"
+	// urlPathTagValue is the struct tag name for url path fields.
+	urlPathTagValue tagValue = "url_path"
+	// urlQueryTagValue is the struct tag name for url query string fields.
+	urlQueryTagValue tagValue = "url_query"
+	// domainTagValue is the struct tag name for domain name fields.
+	domainTagValue tagValue = "domain"
+	// inFileTagValue is the struct tag name for fields whose values must exist in a lookup file.
+	inFileTagValue tagValue = "in_file"
+	// numericUnicodeTagValue is the struct tag name for unicode digit fields.
+	numericUnicodeTagValue tagValue = "numeric_unicode"
+	// noWhitespaceTagValue is the struct tag name for fields without white space.
+	noWhitespaceTagValue tagValue = "nowhitespace"
+	// singleLineTagValue is the struct tag name for fields without line breaks.
+	singleLineTagValue tagValue = "singleline"
+	// excludedIfTagValue is the struct tag name for fields that must be empty if other fields have the specified values.
+	excludedIfTagValue tagValue = "excluded_if"
+	// excludedWithTagValue is the struct tag name for fields that must be empty if any of other fields is not empty.
+	excludedWithTagValue tagValue = "excluded_with"
+	// luhnTagValue is the struct tag name for fields validated by the Luhn algorithm.
+	luhnTagValue tagValue = "luhn"
+	// mod97TagValue is the struct tag name for fields validated by ISO 7064 MOD 97-10.
+	mod97TagValue tagValue = "mod97"
+	// dammTagValue is the struct tag name for fields validated by the Damm algorithm.
+	dammTagValue tagValue = "damm"
+	// checksumTagValue is the struct tag name for fields validated by the specified checksum algorithm.
+	checksumTagValue tagValue = "checksum"
+	// itemSeparatorTagValue is the struct tag name for the separator of list fields.
+	itemSeparatorTagValue tagValue = "item_sep"
+	// uniqueItemsTagValue is the struct tag name for list fields without duplicate items.
+	uniqueItemsTagValue tagValue = "unique_items"
+	// minItemsTagValue is the struct tag name for list fields with minimum number of items.
+	minItemsTagValue tagValue = "min_items"
+	// maxItemsTagValue is the struct tag name for list fields with maximum number of items.
+	maxItemsTagValue tagValue = "max_items"
+	// alphaUnicodeSpaceTagValue is the struct tag name for unicode letters and space fields.
+	alphaUnicodeSpaceTagValue tagValue = "alphaunicodespace"
+	// personNameTagValue is the struct tag name for person name fields.
+	personNameTagValue tagValue = "person_name"
+	// idFormatTagValue is the struct tag name for identifier fields with a prefix and a number.
+	idFormatTagValue tagValue = "id_format"
+	// dateTagValue is the struct tag name for date fields.
+	dateTagValue tagValue = "date"
+	// dateTimeTagValue is the struct tag name for datetime fields.
+	dateTimeTagValue tagValue = "datetime"
+	// timeTagValue is the struct tag name for time fields.
+	timeTagValue tagValue = "time"
+	// timezoneTagValue is the struct tag name for the timezone of date, datetime or time fields.
+	timezoneTagValue tagValue = "timezone"
+	// beforeTagValue is the struct tag name for date, datetime or time fields that must be before the time.
+	beforeTagValue tagValue = "before"
+	// afterTagValue is the struct tag name for date, datetime or time fields that must be after the time.
+	afterTagValue tagValue = "after"
+	// withinDaysTagValue is the struct tag name for date or datetime fields that must be within the days from now.
+	withinDaysTagValue tagValue = "within_days"
+	// colMeanGTETagValue is the struct tag name for the minimum mean of the column.
+	colMeanGTETagValue tagValue = "col_mean_gte"
+	// colMeanLTETagValue is the struct tag name for the maximum mean of the column.
+	colMeanLTETagValue tagValue = "col_mean_lte"
+	// colStddevGTETagValue is the struct tag name for the minimum standard deviation of the column.
+	colStddevGTETagValue tagValue = "col_stddev_gte"
+	// colStddevLTETagValue is the struct tag name for the maximum standard deviation of the column.
+	colStddevLTETagValue tagValue = "col_stddev_lte"
+	// digitsTagValue is the struct tag name for the fields of the fixed number of digits.
+	digitsTagValue tagValue = "digits"
+	// numericLenTagValue is the struct tag name for the fields of the range of the number of digits.
+	numericLenTagValue tagValue = "numeric_len"
+	// zenkakuTagValue is the struct tag name for full-width fields.
+	zenkakuTagValue tagValue = "zenkaku"
+	// hankakuTagValue is the struct tag name for half-width fields.
+	hankakuTagValue tagValue = "hankaku"
+	// katakanaTagValue is the struct tag name for full-width katakana fields.
+	katakanaTagValue tagValue = "katakana"
+	// hiraganaTagValue is the struct tag name for hiragana fields.
+	hiraganaTagValue tagValue = "hiragana"
+	// md5TagValue is the struct tag name for MD5 digest fields.
+	md5TagValue tagValue = "md5"
+	// sha1TagValue is the struct tag name for SHA-1 digest fields.
+	sha1TagValue tagValue = "sha1"
+	// sha256TagValue is the struct tag name for SHA-256 digest fields.
+	sha256TagValue tagValue = "sha256"
+	// sha384TagValue is the struct tag name for SHA-384 digest fields.
+	sha384TagValue tagValue = "sha384"
+	// sha512TagValue is the struct tag name for SHA-512 digest fields.
+	sha512TagValue tagValue = "sha512"
+	// ipPrivateTagValue is the struct tag name for private IP address fields.
+	ipPrivateTagValue tagValue = "ip_private"
+	// ipPublicTagValue is the struct tag name for public IP address fields.
+	ipPublicTagValue tagValue = "ip_public"
+	// ipLoopbackTagValue is the struct tag name for loopback IP address fields.
+	ipLoopbackTagValue tagValue = "ip_loopback"
+	// greaterThanFieldTagValue is the struct tag name for fields that must be greater than other field.
+	greaterThanFieldTagValue tagValue = "gtfield"
+	// greaterThanEqualFieldTagValue is the struct tag name for fields that must be greater than or equal to other field.
+	greaterThanEqualFieldTagValue tagValue = "gtefield"
+	// lessThanFieldTagValue is the struct tag name for fields that must be less than other field.
+	lessThanFieldTagValue tagValue = "ltfield"
+	// lessThanEqualFieldTagValue is the struct tag name for fields that must be less than or equal to other field.
+	lessThanEqualFieldTagValue tagValue = "ltefield"
 )
 
+const (
+	// trimTagValue is the normalize tag value for trimming leading and trailing white space.
+	trimTagValue tagValue = "trim"
+	// lowerTagValue is the normalize tag value for converting to lowercase.
+	lowerTagValue tagValue = "lower"
+	// upperTagValue is the normalize tag value for converting to uppercase.
+	upperTagValue tagValue = "upper"
+)
+
+// validateTagValues is the names of the rules of the validate tag. It is used by CheckStruct
+// to report the unknown rules.
+var validateTagValues = []tagValue{
+	booleanTagValue,
+	alphaTagValue,
+	numericTagValue,
+	alphanumericTagValue,
+	requiredTagValue,
+	equalTagValue,
+	notEqualTagValue,
+	greaterThanTagValue,
+	greaterThanEqualTagValue,
+	lessThanTagValue,
+	lessThanEqualTagValue,
+	minTagValue,
+	maxTagValue,
+	lengthTagValue,
+	minLengthTagValue,
+	maxLengthTagValue,
+	oneOfTagValue,
+	oneOfCITagValue,
+	oneOfNumTagValue,
+	lowercaseTagValue,
+	uppercaseTagValue,
+	asciiTagValue,
+	emailTagValue,
+	containsTagValue,
+	containsAnyTagValue,
+	htmlEncodedTagValue,
+	xmlTagValue,
+	urlPathTagValue,
+	urlQueryTagValue,
+	domainTagValue,
+	inFileTagValue,
+	numericUnicodeTagValue,
+	noWhitespaceTagValue,
+	singleLineTagValue,
+	excludedIfTagValue,
+	excludedWithTagValue,
+	luhnTagValue,
+	mod97TagValue,
+	dammTagValue,
+	checksumTagValue,
+	itemSeparatorTagValue,
+	uniqueItemsTagValue,
+	minItemsTagValue,
+	maxItemsTagValue,
+	alphaUnicodeSpaceTagValue,
+	personNameTagValue,
+	idFormatTagValue,
+	dateTagValue,
+	dateTimeTagValue,
+	timeTagValue,
+	timezoneTagValue,
+	beforeTagValue,
+	afterTagValue,
+	withinDaysTagValue,
+	colMeanGTETagValue,
+	colMeanLTETagValue,
+	colStddevGTETagValue,
+	colStddevLTETagValue,
+	digitsTagValue,
+	numericLenTagValue,
+	zenkakuTagValue,
+	hankakuTagValue,
+	katakanaTagValue,
+	hiraganaTagValue,
+	md5TagValue,
+	sha1TagValue,
+	sha256TagValue,
+	sha384TagValue,
+	sha512TagValue,
+	ipPrivateTagValue,
+	ipPublicTagValue,
+	ipLoopbackTagValue,
+	greaterThanFieldTagValue,
+	greaterThanEqualFieldTagValue,
+	lessThanFieldTagValue,
+	lessThanEqualFieldTagValue,
+}
+
 // String returns the string representation of the tag.
 func (t tag) String() string {
 	return string(t)
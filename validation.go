@@ -1,13 +1,22 @@
 package csv
 
 import (
+	"context"
+	"encoding/xml"
 	"fmt"
+	"html"
+	"io"
+	"net/netip"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/rivo/uniseg"
+	"golang.org/x/text/width"
 )
 
 // validator is a struct that contains the validation rules for a column.
@@ -18,6 +27,42 @@ type validator interface {
 	Do(localizer *i18n.Localizer, target any) error
 }
 
+// contextValidator is the interface for validators that honor the cancellation of the context,
+// e.g. the validators that look up large tables or call user functions.
+type contextValidator interface {
+	validator
+	DoContext(ctx context.Context, localizer *i18n.Localizer, target any) error
+}
+
+// doContext validates the target with the context. The validators that do not implement
+// contextValidator are called through Do, so every validator can be used as a contextValidator.
+func doContext(ctx context.Context, v validator, localizer *i18n.Localizer, target any) error {
+	if cv, ok := v.(contextValidator); ok {
+		return cv.DoContext(ctx, localizer, target)
+	}
+	return v.Do(localizer, target)
+}
+
+// crossFieldValidator is the interface for validators that refer to other fields in the same record.
+type crossFieldValidator interface {
+	validator
+	// fields returns the struct field names that the validator refers to.
+	fields() []string
+	// bind sets the column indexes of the referred fields. The order is the same as fields().
+	bind(indexes []int)
+	// DoWithRecord validates the target with the record that contains the target.
+	DoWithRecord(localizer *i18n.Localizer, target any, record []string) error
+}
+
+// fieldValue returns the value of the column in the record.
+// If the record does not have the column, it returns an empty string.
+func fieldValue(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+	return record[index]
+}
+
 // booleanValidator is a struct that contains the validation rules for a boolean column.
 type booleanValidator struct{}
 
@@ -385,6 +430,92 @@ func (l *lengthValidator) Do(localizer *i18n.Localizer, target any) error {
 	return nil
 }
 
+// minLengthValidator is a struct that contains the validation rules for a minimum length column.
+type minLengthValidator struct {
+	threshold float64
+}
+
+// newMinLengthValidator returns a new minLengthValidator.
+func newMinLengthValidator(threshold float64) *minLengthValidator {
+	return &minLengthValidator{threshold: threshold}
+}
+
+// Do validates the target length is greater than or equal to the threshold.
+// The length is the number of grapheme clusters, so "👨‍👩‍👧" is one character.
+func (m *minLengthValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrMinLengthID, fmt.Sprintf("value=%v", target))
+	}
+
+	if uniseg.GraphemeClusterCount(v) < int(m.threshold) {
+		return NewError(localizer, ErrMinLengthID, fmt.Sprintf("length threshold=%v, value=%v", m.threshold, target))
+	}
+	return nil
+}
+
+// maxLengthValidator is a struct that contains the validation rules for a maximum length column.
+type maxLengthValidator struct {
+	threshold float64
+}
+
+// newMaxLengthValidator returns a new maxLengthValidator.
+func newMaxLengthValidator(threshold float64) *maxLengthValidator {
+	return &maxLengthValidator{threshold: threshold}
+}
+
+// Do validates the target length is less than or equal to the threshold.
+// The length is the number of grapheme clusters.
+func (m *maxLengthValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrMaxLengthID, fmt.Sprintf("value=%v", target))
+	}
+
+	if uniseg.GraphemeClusterCount(v) > int(m.threshold) {
+		return NewError(localizer, ErrMaxLengthID, fmt.Sprintf("length threshold=%v, value=%v", m.threshold, target))
+	}
+	return nil
+}
+
+// digitsValidator is a struct that contains the validation rules for a column of digits.
+// The leading zeros are kept, so "000123" is 6 digits.
+type digitsValidator struct {
+	min int
+	max int
+}
+
+// newDigitsValidator returns a new digitsValidator.
+func newDigitsValidator(min, max int) *digitsValidator {
+	return &digitsValidator{min: min, max: max}
+}
+
+// Do validates the target only contains ASCII digits and the number of digits is between min and max.
+func (d *digitsValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrDigitsID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if r < '0' || r > '9' {
+			return NewError(localizer, ErrDigitsID, fmt.Sprintf("%s, value=%v", d, target))
+		}
+	}
+	if len(v) < d.min || len(v) > d.max {
+		return NewError(localizer, ErrDigitsID, fmt.Sprintf("%s, value=%v", d, target))
+	}
+	return nil
+}
+
+// String returns the rule of the validator. e.g. digits=6, numeric_len=3:8
+func (d *digitsValidator) String() string {
+	if d.min == d.max {
+		return fmt.Sprintf("%s=%d", digitsTagValue, d.min)
+	}
+	return fmt.Sprintf("%s=%d:%d", numericLenTagValue, d.min, d.max)
+}
+
 // oneOfValidator is a struct that contains the validation rules for a one of column.
 type oneOfValidator struct {
 	oneOf []string
@@ -407,7 +538,75 @@ func (o *oneOfValidator) Do(localizer *i18n.Localizer, target any) error {
 			return nil
 		}
 	}
-	return NewError(localizer, ErrOneOfID, fmt.Sprintf("oneof=%s, value=%v", strings.Join(o.oneOf, " "), target))
+	subMessage := fmt.Sprintf("oneof=%s, value=%v", joinSpecifiedValues(o.oneOf), target)
+	return NewError(localizer, ErrOneOfID, withSuggestion(localizer, subMessage, v, o.oneOf))
+}
+
+// oneOfNumValidator is a struct that contains the validation rules for a one of column
+// compared numerically.
+type oneOfNumValidator struct {
+	oneOf []string
+}
+
+// newOneOfNumValidator returns a new oneOfNumValidator.
+func newOneOfNumValidator(oneOf []string) *oneOfNumValidator {
+	return &oneOfNumValidator{oneOf: oneOf}
+}
+
+// Do validates the target is one of the oneOf values. The numbers are compared numerically,
+// e.g. "1.0" is equal to "1".
+func (o *oneOfNumValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrOneOfID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, s := range o.oneOf {
+		if v == s || equalNumber(v, s) {
+			return nil
+		}
+	}
+	return NewError(localizer, ErrOneOfID, fmt.Sprintf("oneofnum=%s, value=%v", joinSpecifiedValues(o.oneOf), target))
+}
+
+// equalNumber reports whether both a and b are numbers and they are equal. e.g. "1" and "1.0"
+func equalNumber(a, b string) bool {
+	x, err := strconv.ParseFloat(a, 64)
+	if err != nil {
+		return false
+	}
+	y, err := strconv.ParseFloat(b, 64)
+	if err != nil {
+		return false
+	}
+	return x == y
+}
+
+// oneOfCIValidator is a struct that contains the validation rules for a one of column
+// compared case-insensitively.
+type oneOfCIValidator struct {
+	oneOf []string
+}
+
+// newOneOfCIValidator returns a new oneOfCIValidator.
+func newOneOfCIValidator(oneOf []string) *oneOfCIValidator {
+	return &oneOfCIValidator{oneOf: oneOf}
+}
+
+// Do validates the target is one of the oneOf values, ignoring case.
+func (o *oneOfCIValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrOneOfID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, s := range o.oneOf {
+		if strings.EqualFold(v, s) {
+			return nil
+		}
+	}
+	subMessage := fmt.Sprintf("oneofci=%s, value=%v", joinSpecifiedValues(o.oneOf), target)
+	return NewError(localizer, ErrOneOfID, withSuggestion(localizer, subMessage, v, o.oneOf))
 }
 
 // lowercaseValidator is a struct that contains the validation rules for a lowercase column.
@@ -521,7 +720,7 @@ func (c *containsValidator) Do(localizer *i18n.Localizer, target any) error {
 	}
 
 	if !strings.Contains(v, c.contains) {
-		return NewError(localizer, ErrContainsID, fmt.Sprintf("contains=%s, value=%v", c.contains, target))
+		return NewError(localizer, ErrContainsID, fmt.Sprintf("contains=%s, value=%v", joinSpecifiedValues([]string{c.contains}), target))
 	}
 	return nil
 }
@@ -548,5 +747,970 @@ func (c *containsAnyValidator) Do(localizer *i18n.Localizer, target any) error {
 			return nil
 		}
 	}
-	return NewError(localizer, ErrContainsAnyID, fmt.Sprintf("containsany=%s, value=%v", strings.Join(c.contains, " "), target))
+	return NewError(localizer, ErrContainsAnyID, fmt.Sprintf("containsany=%s, value=%v", joinSpecifiedValues(c.contains), target))
+}
+
+// htmlEncodedValidator is a struct that contains the validation rules for an HTML encoded column.
+type htmlEncodedValidator struct {
+	regexp *regexp.Regexp
+}
+
+// newHTMLEncodedValidator returns a new htmlEncodedValidator.
+func newHTMLEncodedValidator() *htmlEncodedValidator {
+	const entityRegexPattern = `^&(#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`
+	return &htmlEncodedValidator{
+		regexp: regexp.MustCompile(entityRegexPattern),
+	}
+}
+
+// Do validates the target is an HTML encoded string.
+// The target must not contain the raw characters <, >, " and ', and every & must
+// start a valid character reference (e.g. &amp;, &#39;, &#x27;).
+func (h *htmlEncodedValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrHTMLEncodedID, fmt.Sprintf("value=%v", target))
+	}
+
+	for i, r := range v {
+		switch r {
+		case '<', '>', '"', '\'':
+			return NewError(localizer, ErrHTMLEncodedID, fmt.Sprintf("value=%v", target))
+		case '&':
+			entity := h.regexp.FindString(v[i:])
+			if entity == "" || html.UnescapeString(entity) == entity {
+				return NewError(localizer, ErrHTMLEncodedID, fmt.Sprintf("value=%v", target))
+			}
+		}
+	}
+	return nil
+}
+
+// xmlValidator is a struct that contains the validation rules for an XML column.
+type xmlValidator struct{}
+
+// newXMLValidator returns a new xmlValidator.
+func newXMLValidator() *xmlValidator {
+	return &xmlValidator{}
+}
+
+// Do validates the target is a well-formed XML.
+func (x *xmlValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrXMLID, fmt.Sprintf("value=%v", target))
+	}
+
+	if v == "" {
+		return nil
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(v))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return NewError(localizer, ErrXMLID, fmt.Sprintf("value=%v", target))
+		}
+	}
+}
+
+// urlPathValidator is a struct that contains the validation rules for a URL path column.
+type urlPathValidator struct{}
+
+// newURLPathValidator returns a new urlPathValidator.
+func newURLPathValidator() *urlPathValidator {
+	return &urlPathValidator{}
+}
+
+// Do validates the target is a URL path that is escaped according to RFC 3986.
+// The target may only contain unreserved characters, sub-delimiters, ':', '@', '/'
+// and percent-encoded octets.
+func (u *urlPathValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrURLPathID, fmt.Sprintf("value=%v", target))
+	}
+
+	if _, err := url.PathUnescape(v); err != nil {
+		return NewError(localizer, ErrURLPathID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if !isURLPathCharacter(r) {
+			return NewError(localizer, ErrURLPathID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	return nil
+}
+
+// isURLPathCharacter returns true if the rune is allowed in an escaped URL path.
+func isURLPathCharacter(r rune) bool {
+	if isAlpha(r) || isNumeric(r) {
+		return true
+	}
+	return strings.ContainsRune("-._~!$&'()*+,;=:@/%", r)
+}
+
+// urlQueryValidator is a struct that contains the validation rules for a URL query string column.
+type urlQueryValidator struct{}
+
+// newURLQueryValidator returns a new urlQueryValidator.
+func newURLQueryValidator() *urlQueryValidator {
+	return &urlQueryValidator{}
+}
+
+// Do validates the target is a URL query string (e.g. "utm_source=news&utm_medium=email").
+// The leading '?' is optional. The target may only contain the characters allowed in
+// an escaped URL path, '?' and percent-encoded octets, and must be parsed by url.ParseQuery.
+func (u *urlQueryValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrURLQueryID, fmt.Sprintf("value=%v", target))
+	}
+
+	query := strings.TrimPrefix(v, "?")
+	for _, r := range query {
+		if !isURLPathCharacter(r) && r != '?' {
+			return NewError(localizer, ErrURLQueryID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	if _, err := url.ParseQuery(query); err != nil {
+		return NewError(localizer, ErrURLQueryID, fmt.Sprintf("value=%v", target))
+	}
+	return nil
+}
+
+// domainValidator is a struct that contains the validation rules for a domain name column.
+type domainValidator struct{}
+
+// newDomainValidator returns a new domainValidator.
+func newDomainValidator() *domainValidator {
+	return &domainValidator{}
+}
+
+// Do validates the target is a domain name without the scheme, the port and the path
+// (e.g. "example.com"). The domain name must have at least two labels, and one trailing dot is allowed.
+// Each label consists of 1 to 63 ASCII letters, digits and hyphens, and does not start or end with a hyphen.
+// The top-level domain must not be all digits.
+func (d *domainValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrDomainID, fmt.Sprintf("value=%v", target))
+	}
+
+	const maxDomainLength = 253
+	domain := strings.TrimSuffix(v, ".")
+	if domain == "" || len(domain) > maxDomainLength {
+		return NewError(localizer, ErrDomainID, fmt.Sprintf("value=%v", target))
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return NewError(localizer, ErrDomainID, fmt.Sprintf("value=%v", target))
+	}
+	for _, label := range labels {
+		if !isDomainLabel(label) {
+			return NewError(localizer, ErrDomainID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	if strings.IndexFunc(labels[len(labels)-1], func(r rune) bool { return !isNumeric(r) }) == -1 {
+		return NewError(localizer, ErrDomainID, fmt.Sprintf("value=%v", target))
+	}
+	return nil
+}
+
+// isDomainLabel returns true if the label is a valid label of a domain name.
+func isDomainLabel(label string) bool {
+	const maxLabelLength = 63
+	if label == "" || len(label) > maxLabelLength {
+		return false
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, r := range label {
+		if !isAlpha(r) && !isNumeric(r) && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupValidator is a struct that contains the validation rules for a lookup column.
+type lookupValidator struct {
+	values map[string]struct{}
+}
+
+// newLookupValidator returns a new lookupValidator.
+func newLookupValidator(values []string) *lookupValidator {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return &lookupValidator{values: set}
+}
+
+// Do validates the target is included in the lookup values.
+// An empty target is valid because the required rule reports it.
+func (l *lookupValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrLookupID, fmt.Sprintf("value=%v", target))
+	}
+
+	if v == "" {
+		return nil
+	}
+	if _, ok := l.values[v]; !ok {
+		return NewError(localizer, ErrLookupID, fmt.Sprintf("value=%v", target))
+	}
+	return nil
+}
+
+// DoContext validates the target is included in the lookup values. It returns the error of
+// the context if the context is canceled.
+func (l *lookupValidator) DoContext(ctx context.Context, localizer *i18n.Localizer, target any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return l.Do(localizer, target)
+}
+
+// numericUnicodeValidator is a struct that contains the validation rules for a unicode digit column.
+type numericUnicodeValidator struct{}
+
+// newNumericUnicodeValidator returns a new numericUnicodeValidator.
+func newNumericUnicodeValidator() *numericUnicodeValidator {
+	return &numericUnicodeValidator{}
+}
+
+// Do validates the target string only contains unicode decimal digits (e.g. "123", "１２３").
+func (n *numericUnicodeValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrNumericUnicodeID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if !unicode.IsDigit(r) {
+			return NewError(localizer, ErrNumericUnicodeID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	return nil
+}
+
+// noWhitespaceValidator is a struct that contains the validation rules for a column without white space.
+type noWhitespaceValidator struct{}
+
+// newNoWhitespaceValidator returns a new noWhitespaceValidator.
+func newNoWhitespaceValidator() *noWhitespaceValidator {
+	return &noWhitespaceValidator{}
+}
+
+// Do validates the target does not contain any white space, including full-width space.
+func (n *noWhitespaceValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrNoWhitespaceID, fmt.Sprintf("value=%v", target))
+	}
+
+	if strings.IndexFunc(v, unicode.IsSpace) != -1 {
+		return NewError(localizer, ErrNoWhitespaceID, fmt.Sprintf("value=%v", target))
+	}
+	return nil
+}
+
+// singleLineValidator is a struct that contains the validation rules for a single line column.
+type singleLineValidator struct{}
+
+// newSingleLineValidator returns a new singleLineValidator.
+func newSingleLineValidator() *singleLineValidator {
+	return &singleLineValidator{}
+}
+
+// Do validates the target does not contain line breaks.
+// The line breaks can be embedded in a quoted value of CSV.
+func (s *singleLineValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrSingleLineID, fmt.Sprintf("value=%q", target))
+	}
+
+	if strings.ContainsAny(v, "\r\n") {
+		return NewError(localizer, ErrSingleLineID, fmt.Sprintf("value=%q", target))
+	}
+	return nil
+}
+
+// excludedIfValidator is a struct that contains the validation rules for an excluded if column.
+type excludedIfValidator struct {
+	// names is the struct field names to compare.
+	names []string
+	// values is the values to compare. The order is the same as names.
+	values []string
+	// indexes is the column indexes of names.
+	indexes []int
+}
+
+// newExcludedIfValidator returns a new excludedIfValidator.
+// params is the pairs of the field name and the value. e.g. ["Status", "closed"]
+func newExcludedIfValidator(params []string) *excludedIfValidator {
+	names := make([]string, 0, len(params)/2)
+	values := make([]string, 0, len(params)/2)
+	for i := 0; i+1 < len(params); i += 2 {
+		names = append(names, params[i])
+		values = append(values, params[i+1])
+	}
+	return &excludedIfValidator{names: names, values: values}
+}
+
+// fields returns the struct field names that the validator refers to.
+func (e *excludedIfValidator) fields() []string {
+	return e.names
+}
+
+// bind sets the column indexes of the referred fields.
+func (e *excludedIfValidator) bind(indexes []int) {
+	e.indexes = indexes
+}
+
+// Do always returns nil because the validator needs the record. Use DoWithRecord instead.
+func (e *excludedIfValidator) Do(_ *i18n.Localizer, _ any) error {
+	return nil
+}
+
+// DoWithRecord validates the target is empty if all the referred fields have the specified values.
+func (e *excludedIfValidator) DoWithRecord(localizer *i18n.Localizer, target any, record []string) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrExcludedIfID, fmt.Sprintf("value=%v", target))
+	}
+
+	if v == "" {
+		return nil
+	}
+	for i, index := range e.indexes {
+		if fieldValue(record, index) != e.values[i] {
+			return nil
+		}
+	}
+
+	conditions := make([]string, 0, len(e.names))
+	for i, name := range e.names {
+		conditions = append(conditions, name+" "+e.values[i])
+	}
+	return NewError(localizer, ErrExcludedIfID, fmt.Sprintf("excluded_if=%s, value=%v", strings.Join(conditions, " "), target))
+}
+
+// excludedWithValidator is a struct that contains the validation rules for an excluded with column.
+type excludedWithValidator struct {
+	// names is the struct field names to check.
+	names []string
+	// indexes is the column indexes of names.
+	indexes []int
+}
+
+// newExcludedWithValidator returns a new excludedWithValidator.
+func newExcludedWithValidator(names []string) *excludedWithValidator {
+	return &excludedWithValidator{names: names}
+}
+
+// fields returns the struct field names that the validator refers to.
+func (e *excludedWithValidator) fields() []string {
+	return e.names
+}
+
+// bind sets the column indexes of the referred fields.
+func (e *excludedWithValidator) bind(indexes []int) {
+	e.indexes = indexes
+}
+
+// Do always returns nil because the validator needs the record. Use DoWithRecord instead.
+func (e *excludedWithValidator) Do(_ *i18n.Localizer, _ any) error {
+	return nil
+}
+
+// DoWithRecord validates the target is empty if any of the referred fields is not empty.
+func (e *excludedWithValidator) DoWithRecord(localizer *i18n.Localizer, target any, record []string) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrExcludedWithID, fmt.Sprintf("value=%v", target))
+	}
+
+	if v == "" {
+		return nil
+	}
+	for _, index := range e.indexes {
+		if fieldValue(record, index) != "" {
+			return NewError(localizer, ErrExcludedWithID, fmt.Sprintf("excluded_with=%s, value=%v", strings.Join(e.names, " "), target))
+		}
+	}
+	return nil
+}
+
+// fieldComparisonValidator is a struct that contains the validation rules for the gtfield, gtefield,
+// ltfield and ltefield tags.
+type fieldComparisonValidator struct {
+	// rule is the struct tag of the rule used in the error message. e.g. gtfield=StartDate
+	rule string
+	// tag is the name of the rule. e.g. gtfield
+	tag tagValue
+	// name is the struct field name to compare.
+	name string
+	// index is the column index of name.
+	index int
+	// layout is the layout of the date, datetime or time tag. If it is empty, the values are compared as numbers.
+	layout string
+}
+
+// newFieldComparisonValidator returns a new fieldComparisonValidator.
+func newFieldComparisonValidator(rule, tag, name, layout string) *fieldComparisonValidator {
+	return &fieldComparisonValidator{rule: rule, tag: tagValue(tag), name: name, layout: layout}
+}
+
+// fields returns the struct field names that the validator refers to.
+func (f *fieldComparisonValidator) fields() []string {
+	return []string{f.name}
+}
+
+// bind sets the column indexes of the referred fields.
+func (f *fieldComparisonValidator) bind(indexes []int) {
+	f.index = indexes[0]
+}
+
+// Do always returns nil because the validator needs the record. Use DoWithRecord instead.
+func (f *fieldComparisonValidator) Do(_ *i18n.Localizer, _ any) error {
+	return nil
+}
+
+// DoWithRecord validates the target is greater (or less) than the referred field. If either value is empty,
+// it returns nil because the required tag reports the error. The time values that can not be parsed are also
+// valid because the date, datetime or time tag reports the error.
+func (f *fieldComparisonValidator) DoWithRecord(localizer *i18n.Localizer, target any, record []string) error {
+	id := f.errorID()
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, id, fmt.Sprintf("%s, value=%v", f.rule, target))
+	}
+
+	other := fieldValue(record, f.index)
+	if v == "" || other == "" {
+		return nil
+	}
+
+	cmp, ok := f.compare(v, other)
+	if !ok {
+		if f.layout != "" {
+			return nil
+		}
+		return NewError(localizer, id, fmt.Sprintf("%s, value=%v", f.rule, target))
+	}
+
+	switch {
+	case f.tag == greaterThanFieldTagValue && cmp > 0,
+		f.tag == greaterThanEqualFieldTagValue && cmp >= 0,
+		f.tag == lessThanFieldTagValue && cmp < 0,
+		f.tag == lessThanEqualFieldTagValue && cmp <= 0:
+		return nil
+	}
+	return NewError(localizer, id, fmt.Sprintf("%s, %s=%s, value=%v", f.rule, f.name, other, target))
+}
+
+// compare returns -1, 0 or +1 depending on whether the target is less than, equal to, or greater than the other.
+// It returns false if either value can not be parsed.
+func (f *fieldComparisonValidator) compare(target, other string) (int, bool) {
+	if f.layout != "" {
+		t, err := time.Parse(f.layout, target)
+		if err != nil {
+			return 0, false
+		}
+		o, err := time.Parse(f.layout, other)
+		if err != nil {
+			return 0, false
+		}
+		return t.Compare(o), true
+	}
+
+	t, err := strconv.ParseFloat(target, 64)
+	if err != nil {
+		return 0, false
+	}
+	o, err := strconv.ParseFloat(other, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch {
+	case t < o:
+		return -1, true
+	case t > o:
+		return 1, true
+	}
+	return 0, true
+}
+
+// errorID returns the error ID of the rule.
+func (f *fieldComparisonValidator) errorID() string {
+	switch f.tag {
+	case greaterThanFieldTagValue:
+		return ErrGreaterThanFieldID
+	case greaterThanEqualFieldTagValue:
+		return ErrGreaterThanEqualFieldID
+	case lessThanFieldTagValue:
+		return ErrLessThanFieldID
+	}
+	return ErrLessThanEqualFieldID
+}
+
+// splitItems splits the target into the items by the separator.
+// The white space around each item is removed. An empty target has no items.
+func splitItems(target, sep string) []string {
+	if target == "" {
+		return []string{}
+	}
+
+	items := strings.Split(target, sep)
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+// uniqueItemsValidator is a struct that contains the validation rules for a list column without duplicate items.
+type uniqueItemsValidator struct {
+	sep string
+}
+
+// newUniqueItemsValidator returns a new uniqueItemsValidator.
+func newUniqueItemsValidator(sep string) *uniqueItemsValidator {
+	return &uniqueItemsValidator{sep: sep}
+}
+
+// Do validates the target does not have duplicate items.
+func (u *uniqueItemsValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrUniqueItemsID, fmt.Sprintf("value=%v", target))
+	}
+
+	seen := make(map[string]struct{})
+	for _, item := range splitItems(v, u.sep) {
+		if _, ok := seen[item]; ok {
+			return NewError(localizer, ErrUniqueItemsID, fmt.Sprintf("duplicate=%s, value=%v", item, target))
+		}
+		seen[item] = struct{}{}
+	}
+	return nil
+}
+
+// minItemsValidator is a struct that contains the validation rules for a list column with minimum number of items.
+type minItemsValidator struct {
+	sep       string
+	threshold float64
+}
+
+// newMinItemsValidator returns a new minItemsValidator.
+func newMinItemsValidator(sep string, threshold float64) *minItemsValidator {
+	return &minItemsValidator{sep: sep, threshold: threshold}
+}
+
+// Do validates the number of items is greater than or equal to the threshold.
+func (m *minItemsValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrMinItemsID, fmt.Sprintf("value=%v", target))
+	}
+
+	if count := len(splitItems(v, m.sep)); float64(count) < m.threshold {
+		return NewError(localizer, ErrMinItemsID, fmt.Sprintf("threshold=%v, items=%d, value=%v", m.threshold, count, target))
+	}
+	return nil
+}
+
+// maxItemsValidator is a struct that contains the validation rules for a list column with maximum number of items.
+type maxItemsValidator struct {
+	sep       string
+	threshold float64
+}
+
+// newMaxItemsValidator returns a new maxItemsValidator.
+func newMaxItemsValidator(sep string, threshold float64) *maxItemsValidator {
+	return &maxItemsValidator{sep: sep, threshold: threshold}
+}
+
+// Do validates the number of items is less than or equal to the threshold.
+func (m *maxItemsValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrMaxItemsID, fmt.Sprintf("value=%v", target))
+	}
+
+	if count := len(splitItems(v, m.sep)); float64(count) > m.threshold {
+		return NewError(localizer, ErrMaxItemsID, fmt.Sprintf("threshold=%v, items=%d, value=%v", m.threshold, count, target))
+	}
+	return nil
+}
+
+// isUnicodeLetter returns true if the rune is a unicode letter or a combining mark (e.g. "e" + U+0301).
+func isUnicodeLetter(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+// isSpaceSeparator returns true if the rune is a space separator, including full-width space.
+// Tabs and line breaks are not space separators.
+func isSpaceSeparator(r rune) bool {
+	return unicode.Is(unicode.Zs, r)
+}
+
+// alphaUnicodeSpaceValidator is a struct that contains the validation rules for a unicode letters and space column.
+type alphaUnicodeSpaceValidator struct{}
+
+// newAlphaUnicodeSpaceValidator returns a new alphaUnicodeSpaceValidator.
+func newAlphaUnicodeSpaceValidator() *alphaUnicodeSpaceValidator {
+	return &alphaUnicodeSpaceValidator{}
+}
+
+// Do validates the target string only contains unicode letters and spaces.
+func (a *alphaUnicodeSpaceValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrAlphaUnicodeSpaceID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if !isUnicodeLetter(r) && !isSpaceSeparator(r) {
+			return NewError(localizer, ErrAlphaUnicodeSpaceID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	return nil
+}
+
+// personNameValidator is a struct that contains the validation rules for a person name column.
+type personNameValidator struct{}
+
+// newPersonNameValidator returns a new personNameValidator.
+func newPersonNameValidator() *personNameValidator {
+	return &personNameValidator{}
+}
+
+// Do validates the target is a person name. e.g. "Jean-Luc Picard", "O'Brien", "山田　太郎"
+// A person name contains at least one unicode letter, and may contain spaces, hyphens and apostrophes.
+func (p *personNameValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrPersonNameID, fmt.Sprintf("value=%v", target))
+	}
+
+	if v == "" {
+		return nil
+	}
+
+	hasLetter := false
+	for _, r := range v {
+		switch {
+		case isUnicodeLetter(r):
+			hasLetter = true
+		case isSpaceSeparator(r), r == '-', r == '\'', r == '’':
+		default:
+			return NewError(localizer, ErrPersonNameID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	if !hasLetter {
+		return NewError(localizer, ErrPersonNameID, fmt.Sprintf("value=%v", target))
+	}
+	return nil
+}
+
+// idFormatVerbRegexp is the regular expression for the format of id_format. e.g. INV-%06d
+var idFormatVerbRegexp = regexp.MustCompile(`^([^%]*)%(0([1-9][0-9]*))?d([^%]*)$`)
+
+// idFormatValidator is a struct that contains the validation rules for an identifier column.
+type idFormatValidator struct {
+	format string
+	regexp *regexp.Regexp
+}
+
+// newIDFormatValidator returns a new idFormatValidator.
+// The format is a literal prefix and suffix with one %d or %0Nd verb. e.g. INV-%06d, PO%d-JP
+// %0Nd matches exactly N digits, and %d matches one or more digits.
+func newIDFormatValidator(format string) (*idFormatValidator, error) {
+	matches := idFormatVerbRegexp.FindStringSubmatch(format)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid id format: %s", format)
+	}
+
+	digits := `[0-9]+`
+	if matches[3] != "" {
+		digits = fmt.Sprintf("[0-9]{%s}", matches[3])
+	}
+	pattern := "^" + regexp.QuoteMeta(matches[1]) + digits + regexp.QuoteMeta(matches[4]) + "$"
+	return &idFormatValidator{
+		format: format,
+		regexp: regexp.MustCompile(pattern),
+	}, nil
+}
+
+// Do validates the target matches the identifier format.
+func (i *idFormatValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrIDFormatID, fmt.Sprintf("id_format=%s, value=%v", i.format, target))
+	}
+
+	if !i.regexp.MatchString(v) {
+		return NewError(localizer, ErrIDFormatID, fmt.Sprintf("id_format=%s, value=%v", i.format, target))
+	}
+	return nil
+}
+
+// isZenkaku returns true if the rune is a full-width character. The ambiguous width characters
+// (e.g. "○", "×") are treated as full-width because they are full-width in Japanese encodings.
+func isZenkaku(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth, width.EastAsianAmbiguous:
+		return true
+	}
+	return false
+}
+
+// isHankaku returns true if the rune is a half-width character (e.g. ASCII, half-width katakana).
+func isHankaku(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianNarrow, width.EastAsianHalfwidth:
+		return true
+	}
+	return false
+}
+
+// prolongedSoundMark is the katakana-hiragana prolonged sound mark "ー".
+// It is used with both katakana and hiragana.
+const prolongedSoundMark = '\u30FC'
+
+// zenkakuValidator is a struct that contains the validation rules for a full-width column.
+type zenkakuValidator struct{}
+
+// newZenkakuValidator returns a new zenkakuValidator.
+func newZenkakuValidator() *zenkakuValidator {
+	return &zenkakuValidator{}
+}
+
+// Do validates the target string only contains full-width characters (e.g. "ＡＢＣ", "山田　太郎").
+func (z *zenkakuValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrZenkakuID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if !isZenkaku(r) {
+			return NewError(localizer, ErrZenkakuID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	return nil
+}
+
+// hankakuValidator is a struct that contains the validation rules for a half-width column.
+type hankakuValidator struct{}
+
+// newHankakuValidator returns a new hankakuValidator.
+func newHankakuValidator() *hankakuValidator {
+	return &hankakuValidator{}
+}
+
+// Do validates the target string only contains half-width characters (e.g. "ABC", "ｱｲｳ").
+func (h *hankakuValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrHankakuID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if !isHankaku(r) {
+			return NewError(localizer, ErrHankakuID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	return nil
+}
+
+// katakanaValidator is a struct that contains the validation rules for a full-width katakana column.
+type katakanaValidator struct{}
+
+// newKatakanaValidator returns a new katakanaValidator.
+func newKatakanaValidator() *katakanaValidator {
+	return &katakanaValidator{}
+}
+
+// Do validates the target string only contains full-width katakana and "ー" (e.g. "ヤマダ", "データ").
+// Half-width katakana is not allowed. Use hankaku for it.
+func (k *katakanaValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrKatakanaID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if r == prolongedSoundMark {
+			continue
+		}
+		if !unicode.Is(unicode.Katakana, r) || isHankaku(r) {
+			return NewError(localizer, ErrKatakanaID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	return nil
+}
+
+// hiraganaValidator is a struct that contains the validation rules for a hiragana column.
+type hiraganaValidator struct{}
+
+// newHiraganaValidator returns a new hiraganaValidator.
+func newHiraganaValidator() *hiraganaValidator {
+	return &hiraganaValidator{}
+}
+
+// Do validates the target string only contains hiragana and "ー" (e.g. "やまだ", "らーめん").
+func (h *hiraganaValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrHiraganaID, fmt.Sprintf("value=%v", target))
+	}
+
+	for _, r := range v {
+		if r == prolongedSoundMark {
+			continue
+		}
+		if !unicode.Is(unicode.Hiragana, r) {
+			return NewError(localizer, ErrHiraganaID, fmt.Sprintf("value=%v", target))
+		}
+	}
+	return nil
+}
+
+// hashLengths is the number of hex characters of the hash digests.
+var hashLengths = map[tagValue]int{
+	md5TagValue:    32,
+	sha1TagValue:   40,
+	sha256TagValue: 64,
+	sha384TagValue: 96,
+	sha512TagValue: 128,
+}
+
+// hashValidator is a struct that contains the validation rules for a hash digest column.
+type hashValidator struct {
+	algorithm tagValue
+	length    int
+}
+
+// newHashValidator returns a new hashValidator.
+func newHashValidator(algorithm tagValue) *hashValidator {
+	return &hashValidator{algorithm: algorithm, length: hashLengths[algorithm]}
+}
+
+// Do validates the target is a hex string of the digest length. The hex string must be
+// all lowercase or all uppercase (e.g. "d41d8cd98f00b204e9800998ecf8427e").
+func (h *hashValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrHashID, fmt.Sprintf("%s, value=%v", h.algorithm, target))
+	}
+
+	if len(v) != h.length {
+		return NewError(localizer, ErrHashID, fmt.Sprintf("%s, value=%v", h.algorithm, target))
+	}
+	var lower, upper bool
+	for _, r := range v {
+		switch {
+		case '0' <= r && r <= '9':
+		case 'a' <= r && r <= 'f':
+			lower = true
+		case 'A' <= r && r <= 'F':
+			upper = true
+		default:
+			return NewError(localizer, ErrHashID, fmt.Sprintf("%s, value=%v", h.algorithm, target))
+		}
+	}
+	if lower && upper {
+		return NewError(localizer, ErrHashID, fmt.Sprintf("%s, value=%v", h.algorithm, target))
+	}
+	return nil
+}
+
+// nonPublicPrefixes are the special-purpose ranges that are global unicast addresses
+// but are not routable on the Internet (RFC 6890, the IANA special-purpose address registries).
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space for CGN
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // TEST-NET-1
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // TEST-NET-2
+	netip.MustParsePrefix("203.0.113.0/24"),  // TEST-NET-3
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved
+	netip.MustParsePrefix("64:ff9b::/96"),    // IPv4-IPv6 translation
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use IPv4-IPv6 translation
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments (e.g. Teredo)
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+	netip.MustParsePrefix("3fff::/20"),       // documentation
+	netip.MustParsePrefix("5f00::/16"),       // segment routing SIDs
+	netip.MustParsePrefix("ff00::/8"),        // multicast
+}
+
+// isPublicAddr returns true if the address is a global unicast address that is not private
+// and not in the special-purpose ranges.
+func isPublicAddr(addr netip.Addr) bool {
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ipClassValidator is a struct that contains the validation rules for an IP address column of a class.
+type ipClassValidator struct {
+	class tagValue
+}
+
+// newIPClassValidator returns a new ipClassValidator. The class is ipPrivateTagValue,
+// ipPublicTagValue or ipLoopbackTagValue.
+func newIPClassValidator(class tagValue) *ipClassValidator {
+	return &ipClassValidator{class: class}
+}
+
+// Do validates the target is an IPv4 or IPv6 address of the class.
+// A private address is in 10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16 or fc00::/7 (RFC 1918, RFC 4193).
+// A loopback address is in 127.0.0.0/8 or ::1. A public address is a global unicast address
+// that is neither private nor in the special-purpose ranges such as 100.64.0.0/10,
+// the TEST-NET ranges, 240.0.0.0/4, 64:ff9b::/96, 2001::/23, 2001:db8::/32 and 2002::/16. IPv4-mapped IPv6 addresses are classified as IPv4 addresses.
+func (iv *ipClassValidator) Do(localizer *i18n.Localizer, target any) error {
+	v, ok := target.(string)
+	if !ok {
+		return NewError(localizer, ErrIPClassID, fmt.Sprintf("%s, value=%v", iv.class, target))
+	}
+
+	addr, err := netip.ParseAddr(v)
+	if err != nil {
+		return NewError(localizer, ErrIPClassID, fmt.Sprintf("%s, value=%v", iv.class, target))
+	}
+	addr = addr.Unmap()
+
+	var valid bool
+	switch iv.class {
+	case ipPrivateTagValue:
+		valid = addr.IsPrivate()
+	case ipPublicTagValue:
+		valid = isPublicAddr(addr)
+	case ipLoopbackTagValue:
+		valid = addr.IsLoopback()
+	}
+	if !valid {
+		return NewError(localizer, ErrIPClassID, fmt.Sprintf("%s, value=%v", iv.class, target))
+	}
+	return nil
 }
@@ -1,6 +1,9 @@
 package csv
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/motemen/go-testutil/dataloc"
@@ -177,3 +180,516 @@ func Test_numericValidator_Do(t *testing.T) {
 		})
 	}
 }
+
+func Test_personNameValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is a name with hyphen", arg: "Jean-Luc Picard", wantErr: false},
+		{name: "should return nil if target is a name with apostrophe", arg: "Miles O'Brien", wantErr: false},
+		{name: "should return nil if target is a name with typographic apostrophe", arg: "D’Angelo", wantErr: false},
+		{name: "should return nil if target is a name with diacritics", arg: "Zoë Saldaña", wantErr: false},
+		{name: "should return nil if target is a japanese name with full-width space", arg: "山田　太郎", wantErr: false},
+		{name: "should return nil if target is an empty string", arg: "", wantErr: false},
+		{name: "should return an error if target contains number", arg: "R2-D2", wantErr: true},
+		{name: "should return an error if target contains tab", arg: "Gina\tYulia", wantErr: true},
+		{name: "should return an error if target has no letter", arg: "- '", wantErr: true},
+		{name: "should return an error if target is not a string", arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := newPersonNameValidator()
+			if err := p.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("personNameValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_alphaUnicodeSpaceValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is unicode letters and spaces", arg: "Zoë Saldaña", wantErr: false},
+		{name: "should return nil if target is japanese with full-width space", arg: "山田　太郎", wantErr: false},
+		{name: "should return an error if target contains hyphen", arg: "Jean-Luc", wantErr: true},
+		{name: "should return an error if target contains number", arg: "abc1", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := newAlphaUnicodeSpaceValidator()
+			if err := a.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("alphaUnicodeSpaceValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_idFormatValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target matches zero-padded number", format: "INV-%06d", arg: "INV-000123", wantErr: false},
+		{name: "should return an error if the width is short", format: "INV-%06d", arg: "INV-123", wantErr: true},
+		{name: "should return an error if the width is long", format: "INV-%06d", arg: "INV-0001234", wantErr: true},
+		{name: "should return an error if the prefix is different", format: "INV-%06d", arg: "PO-000123", wantErr: true},
+		{name: "should return nil if target matches number with any width", format: "PO%d-JP", arg: "PO12-JP", wantErr: false},
+		{name: "should return an error if the suffix is missing", format: "PO%d-JP", arg: "PO12", wantErr: true},
+		{name: "should return an error if the prefix has regexp meta characters", format: "A.B%02d", arg: "AxB01", wantErr: true},
+		{name: "should return an error if target is not a string", format: "INV-%06d", arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v, err := newIDFormatValidator(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := v.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("idFormatValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+
+	t.Run("should return an error if the format is invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, format := range []string{"INV-", "INV-%s", "INV-%6d", "%d-%d"} {
+			if _, err := newIDFormatValidator(format); err == nil {
+				t.Errorf("newIDFormatValidator(%q) got nil error", format)
+			}
+		}
+	})
+}
+
+func Test_minLengthValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		threshold float64
+		arg       any
+		wantErr   bool
+	}{
+		{name: "should return nil if target length is equal to the threshold", threshold: 3, arg: "abc", wantErr: false},
+		{name: "should return nil if target length is greater than the threshold", threshold: 3, arg: "abcd", wantErr: false},
+		{name: "should return an error if target length is less than the threshold", threshold: 3, arg: "ab", wantErr: true},
+		{name: "should count multi-byte characters as one", threshold: 3, arg: "あいう", wantErr: false},
+		{name: "should count a grapheme cluster as one", threshold: 2, arg: "👨‍👩‍👧", wantErr: true},
+		{name: "should return an error if target is empty", threshold: 1, arg: "", wantErr: true},
+		{name: "should return an error if target is not a string", threshold: 1, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newMinLengthValidator(tt.threshold)
+			if err := m.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("minLengthValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_maxLengthValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		threshold float64
+		arg       any
+		wantErr   bool
+	}{
+		{name: "should return nil if target length is equal to the threshold", threshold: 3, arg: "abc", wantErr: false},
+		{name: "should return nil if target is empty", threshold: 3, arg: "", wantErr: false},
+		{name: "should return an error if target length is greater than the threshold", threshold: 3, arg: "abcd", wantErr: true},
+		{name: "should count a grapheme cluster as one", threshold: 1, arg: "👨‍👩‍👧", wantErr: false},
+		{name: "should return an error if target is not a string", threshold: 1, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newMaxLengthValidator(tt.threshold)
+			if err := m.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("maxLengthValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_doContext(t *testing.T) {
+	t.Parallel()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		v       validator
+		arg     any
+		wantErr error
+	}{
+		{name: "context validator", ctx: context.Background(), v: newLookupValidator([]string{"JP"}), arg: "JP", wantErr: nil},
+		{name: "context validator with canceled context", ctx: canceled, v: newLookupValidator([]string{"JP"}), arg: "JP", wantErr: context.Canceled},
+		{name: "validator without context", ctx: canceled, v: newAlphaValidator(), arg: "abc", wantErr: nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := doContext(tt.ctx, tt.v, helperLocalizer(t), tt.arg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("doContext() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_lookupValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is one of the lookup values", values: []string{"JP", "US"}, arg: "JP", wantErr: false},
+		{name: "should return nil if target is empty", values: []string{"JP", "US"}, arg: "", wantErr: false},
+		{name: "should return an error if target is not one of the lookup values", values: []string{"JP", "US"}, arg: "FR", wantErr: true},
+		{name: "should return an error if target is not a string", values: []string{"1"}, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l := newLookupValidator(tt.values)
+			if err := l.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("lookupValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_oneOfValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		oneOf   []string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is one of the values", oneOf: []string{"male", "female"}, arg: "male", wantErr: false},
+		{name: "should return an error if target has different case", oneOf: []string{"male", "female"}, arg: "Male", wantErr: true},
+		{name: "should return nil if target is the value with spaces", oneOf: []string{"not available", "ok"}, arg: "not available", wantErr: false},
+		{name: "should return an error if target is numerically equal but different", oneOf: []string{"1", "2"}, arg: "1.0", wantErr: true},
+		{name: "should return an error if target has leading zeros", oneOf: []string{"1", "2"}, arg: "001", wantErr: true},
+		{name: "should return an error if target is not a string", oneOf: []string{"1"}, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			o := newOneOfValidator(tt.oneOf)
+			if err := o.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("oneOfValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_oneOfCIValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		oneOf   []string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is one of the values", oneOf: []string{"male", "female"}, arg: "male", wantErr: false},
+		{name: "should return nil if target has different case", oneOf: []string{"male", "female"}, arg: "FeMale", wantErr: false},
+		{name: "should return an error if target is not one of the values", oneOf: []string{"male", "female"}, arg: "other", wantErr: true},
+		{name: "should return an error if target is not a string", oneOf: []string{"male"}, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			o := newOneOfCIValidator(tt.oneOf)
+			if err := o.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("oneOfCIValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_oneOfNumValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		oneOf   []string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is one of the values", oneOf: []string{"1", "2"}, arg: "2", wantErr: false},
+		{name: "should return nil if target is numerically equal", oneOf: []string{"1", "2"}, arg: "1.0", wantErr: false},
+		{name: "should return nil if target has leading zeros", oneOf: []string{"1", "2"}, arg: "001", wantErr: false},
+		{name: "should return an error if target is not numerically equal", oneOf: []string{"1", "2"}, arg: "3", wantErr: true},
+		{name: "should return an error if target is not a number", oneOf: []string{"1", "2"}, arg: "one", wantErr: true},
+		{name: "should return an error if target is not a string", oneOf: []string{"1"}, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			o := newOneOfNumValidator(tt.oneOf)
+			if err := o.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("oneOfNumValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_digitsValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		min     int
+		max     int
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target has leading zeros", min: 6, max: 6, arg: "000123", wantErr: false},
+		{name: "should return an error if target is short", min: 6, max: 6, arg: "123", wantErr: true},
+		{name: "should return an error if target is long", min: 6, max: 6, arg: "0001234", wantErr: true},
+		{name: "should return nil if target length is in the range", min: 3, max: 8, arg: "0012", wantErr: false},
+		{name: "should return an error if target has a sign", min: 3, max: 8, arg: "-0012", wantErr: true},
+		{name: "should return an error if target has a decimal point", min: 3, max: 8, arg: "12.5", wantErr: true},
+		{name: "should return an error if target has full-width digits", min: 1, max: 8, arg: "１２３", wantErr: true},
+		{name: "should return an error if target is empty", min: 1, max: 8, arg: "", wantErr: true},
+		{name: "should return an error if target is not a string", min: 1, max: 8, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := newDigitsValidator(tt.min, tt.max)
+			if err := d.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("digitsValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_japaneseWidthValidators_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		validator validator
+		arg       any
+		wantErr   bool
+	}{
+		{name: "zenkaku: full-width alphabet and space", validator: newZenkakuValidator(), arg: "ＡＢＣ　山田", wantErr: false},
+		{name: "zenkaku: ambiguous width symbol", validator: newZenkakuValidator(), arg: "○×", wantErr: false},
+		{name: "zenkaku: half-width alphabet", validator: newZenkakuValidator(), arg: "山田A", wantErr: true},
+		{name: "zenkaku: half-width katakana", validator: newZenkakuValidator(), arg: "ｱｲｳ", wantErr: true},
+		{name: "zenkaku: not a string", validator: newZenkakuValidator(), arg: 1, wantErr: true},
+		{name: "hankaku: ASCII and half-width katakana", validator: newHankakuValidator(), arg: "ABC 123 ｱｲｳ", wantErr: false},
+		{name: "hankaku: full-width digits", validator: newHankakuValidator(), arg: "１２３", wantErr: true},
+		{name: "hankaku: not a string", validator: newHankakuValidator(), arg: 1, wantErr: true},
+		{name: "katakana: full-width katakana with prolonged sound mark", validator: newKatakanaValidator(), arg: "データ", wantErr: false},
+		{name: "katakana: half-width katakana", validator: newKatakanaValidator(), arg: "ﾃﾞｰﾀ", wantErr: true},
+		{name: "katakana: hiragana", validator: newKatakanaValidator(), arg: "やまだ", wantErr: true},
+		{name: "katakana: empty", validator: newKatakanaValidator(), arg: "", wantErr: false},
+		{name: "hiragana: hiragana with prolonged sound mark", validator: newHiraganaValidator(), arg: "らーめん", wantErr: false},
+		{name: "hiragana: katakana", validator: newHiraganaValidator(), arg: "ヤマダ", wantErr: true},
+		{name: "hiragana: kanji", validator: newHiraganaValidator(), arg: "山田", wantErr: true},
+		{name: "hiragana: not a string", validator: newHiraganaValidator(), arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.validator.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("validator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_hashValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		algorithm tagValue
+		arg       any
+		wantErr   bool
+	}{
+		{name: "md5: lowercase", algorithm: md5TagValue, arg: "d41d8cd98f00b204e9800998ecf8427e", wantErr: false},
+		{name: "md5: uppercase", algorithm: md5TagValue, arg: "D41D8CD98F00B204E9800998ECF8427E", wantErr: false},
+		{name: "md5: mixed case", algorithm: md5TagValue, arg: "D41d8cd98f00b204e9800998ecf8427e", wantErr: true},
+		{name: "md5: too short", algorithm: md5TagValue, arg: "d41d8cd98f00b204e9800998ecf8427", wantErr: true},
+		{name: "md5: not hex", algorithm: md5TagValue, arg: "g41d8cd98f00b204e9800998ecf8427e", wantErr: true},
+		{name: "sha1: valid", algorithm: sha1TagValue, arg: "da39a3ee5e6b4b0d3255bfef95601890afd80709", wantErr: false},
+		{name: "sha256: valid", algorithm: sha256TagValue, arg: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", wantErr: false},
+		{name: "sha256: md5 length", algorithm: sha256TagValue, arg: "d41d8cd98f00b204e9800998ecf8427e", wantErr: true},
+		{name: "sha384: valid", algorithm: sha384TagValue, arg: strings.Repeat("a", 96), wantErr: false},
+		{name: "sha512: valid", algorithm: sha512TagValue, arg: strings.Repeat("0", 128), wantErr: false},
+		{name: "empty", algorithm: md5TagValue, arg: "", wantErr: true},
+		{name: "not a string", algorithm: md5TagValue, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := newHashValidator(tt.algorithm)
+			if err := h.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("hashValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_urlQueryValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is a query string", arg: "utm_source=news&utm_medium=email", wantErr: false},
+		{name: "should return nil if target has a leading question mark", arg: "?q=go%20csv&page=2", wantErr: false},
+		{name: "should return nil if target is empty", arg: "", wantErr: false},
+		{name: "should return an error if target has a broken escape", arg: "q=%zz", wantErr: true},
+		{name: "should return an error if target has a space", arg: "q=go csv", wantErr: true},
+		{name: "should return an error if target has a semicolon separator", arg: "a=1;b=2", wantErr: true},
+		{name: "should return an error if target is not a string", arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			u := newURLQueryValidator()
+			if err := u.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("urlQueryValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_domainValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     any
+		wantErr bool
+	}{
+		{name: "should return nil if target is a domain", arg: "example.com", wantErr: false},
+		{name: "should return nil if target is a subdomain with a hyphen", arg: "api-v2.example.co.jp", wantErr: false},
+		{name: "should return nil if target has a trailing dot", arg: "example.com.", wantErr: false},
+		{name: "should return an error if target has a scheme", arg: "https://example.com", wantErr: true},
+		{name: "should return an error if target has a port", arg: "example.com:8080", wantErr: true},
+		{name: "should return an error if target has a path", arg: "example.com/path", wantErr: true},
+		{name: "should return an error if target has one label", arg: "localhost", wantErr: true},
+		{name: "should return an error if target has an empty label", arg: "example..com", wantErr: true},
+		{name: "should return an error if a label starts with a hyphen", arg: "-example.com", wantErr: true},
+		{name: "should return an error if a label is too long", arg: strings.Repeat("a", 64) + ".com", wantErr: true},
+		{name: "should return an error if target is an IP address", arg: "192.168.0.1", wantErr: true},
+		{name: "should return an error if target is empty", arg: "", wantErr: true},
+		{name: "should return an error if target is not a string", arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := newDomainValidator()
+			if err := d.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("domainValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}
+
+func Test_ipClassValidator_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		class   tagValue
+		arg     any
+		wantErr bool
+	}{
+		{name: "ip_private: 10.0.0.0/8", class: ipPrivateTagValue, arg: "10.1.2.3", wantErr: false},
+		{name: "ip_private: 172.16.0.0/12", class: ipPrivateTagValue, arg: "172.31.255.255", wantErr: false},
+		{name: "ip_private: 192.168.0.0/16", class: ipPrivateTagValue, arg: "192.168.0.1", wantErr: false},
+		{name: "ip_private: unique local IPv6", class: ipPrivateTagValue, arg: "fd00::1", wantErr: false},
+		{name: "ip_private: public", class: ipPrivateTagValue, arg: "8.8.8.8", wantErr: true},
+		{name: "ip_private: outside 172.16.0.0/12", class: ipPrivateTagValue, arg: "172.32.0.1", wantErr: true},
+		{name: "ip_public: IPv4", class: ipPublicTagValue, arg: "8.8.8.8", wantErr: false},
+		{name: "ip_public: IPv6", class: ipPublicTagValue, arg: "2001:4860:4860::8888", wantErr: false},
+		{name: "ip_public: IPv4-mapped IPv6", class: ipPublicTagValue, arg: "::ffff:8.8.8.8", wantErr: false},
+		{name: "ip_public: private", class: ipPublicTagValue, arg: "10.0.0.1", wantErr: true},
+		{name: "ip_public: loopback", class: ipPublicTagValue, arg: "127.0.0.1", wantErr: true},
+		{name: "ip_public: link local", class: ipPublicTagValue, arg: "169.254.0.1", wantErr: true},
+		{name: "ip_public: unspecified", class: ipPublicTagValue, arg: "0.0.0.0", wantErr: true},
+		{name: "ip_public: this network", class: ipPublicTagValue, arg: "0.1.2.3", wantErr: true},
+		{name: "ip_public: shared address space", class: ipPublicTagValue, arg: "100.64.0.1", wantErr: true},
+		{name: "ip_public: TEST-NET-1", class: ipPublicTagValue, arg: "192.0.2.1", wantErr: true},
+		{name: "ip_public: TEST-NET-2", class: ipPublicTagValue, arg: "198.51.100.1", wantErr: true},
+		{name: "ip_public: TEST-NET-3", class: ipPublicTagValue, arg: "203.0.113.1", wantErr: true},
+		{name: "ip_public: benchmarking", class: ipPublicTagValue, arg: "198.18.0.1", wantErr: true},
+		{name: "ip_public: reserved", class: ipPublicTagValue, arg: "240.0.0.1", wantErr: true},
+		{name: "ip_public: IPv6 documentation", class: ipPublicTagValue, arg: "2001:db8::1", wantErr: true},
+		{name: "ip_public: IPv4-IPv6 translation", class: ipPublicTagValue, arg: "64:ff9b::808:808", wantErr: true},
+		{name: "ip_public: discard-only", class: ipPublicTagValue, arg: "100::1", wantErr: true},
+		{name: "ip_public: Teredo", class: ipPublicTagValue, arg: "2001::1", wantErr: true},
+		{name: "ip_public: IETF protocol assignments", class: ipPublicTagValue, arg: "2001:1ff::1", wantErr: true},
+		{name: "ip_public: 6to4", class: ipPublicTagValue, arg: "2002:c000:204::1", wantErr: true},
+		{name: "ip_public: IPv6 documentation 3fff::/20", class: ipPublicTagValue, arg: "3fff::1", wantErr: true},
+		{name: "ip_public: IPv6 multicast", class: ipPublicTagValue, arg: "ff02::1", wantErr: true},
+		{name: "ip_public: next to IETF protocol assignments", class: ipPublicTagValue, arg: "2001:200::1", wantErr: false},
+		{name: "ip_public: next to shared address space", class: ipPublicTagValue, arg: "100.128.0.1", wantErr: false},
+		{name: "ip_loopback: IPv4", class: ipLoopbackTagValue, arg: "127.0.0.53", wantErr: false},
+		{name: "ip_loopback: IPv6", class: ipLoopbackTagValue, arg: "::1", wantErr: false},
+		{name: "ip_loopback: private", class: ipLoopbackTagValue, arg: "192.168.0.1", wantErr: true},
+		{name: "not an IP address", class: ipPublicTagValue, arg: "example.com", wantErr: true},
+		{name: "empty", class: ipLoopbackTagValue, arg: "", wantErr: true},
+		{name: "not a string", class: ipPrivateTagValue, arg: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			iv := newIPClassValidator(tt.class)
+			if err := iv.Do(helperLocalizer(t), tt.arg); (err != nil) != tt.wantErr {
+				t.Errorf("ipClassValidator.Do() error = %v, wantErr %v, test case at %s", err, tt.wantErr, dataloc.L(tt.name))
+			}
+		})
+	}
+}